@@ -507,41 +507,21 @@ func (h *WorkloadResourceHandler) processWorkloadEvent(event WorkloadEvent) erro
 
 // handleDynamicGVRDiscovery implements business logic for dynamic resource discovery
 func (h *WorkloadResourceHandler) handleDynamicGVRDiscovery(event faro.MatchedEvent) {
-	// Business logic: Extract GVR from Kubernetes events
-	if involvedObj, ok := event.Object.Object["involvedObject"].(map[string]interface{}); ok {
-		if discoveredGVR := h.extractGVRFromEvent(involvedObj); discoveredGVR != "" {
-			h.detector.mu.Lock()
-			if !h.detector.dynamicGVRs[discoveredGVR] {
-				h.detector.dynamicGVRs[discoveredGVR] = true
-				h.detector.logger.Info("dynamic-discovery", 
-					fmt.Sprintf("🔍 Discovered new GVR: %s for workload %s", discoveredGVR, h.workloadID))
-				
-				// Business logic: Add discovered GVR to monitoring
-				go h.addDiscoveredGVR(discoveredGVR)
-			}
-			h.detector.mu.Unlock()
+	// Business logic: Extract GVR from Kubernetes events (plural resolved via API discovery)
+	if discoveredGVR, _, _, ok := h.detector.workloadController.InvolvedObjectGVR(event.Object); ok {
+		h.detector.mu.Lock()
+		if !h.detector.dynamicGVRs[discoveredGVR] {
+			h.detector.dynamicGVRs[discoveredGVR] = true
+			h.detector.logger.Info("dynamic-discovery", 
+				fmt.Sprintf("🔍 Discovered new GVR: %s for workload %s", discoveredGVR, h.workloadID))
+			
+			// Business logic: Add discovered GVR to monitoring
+			go h.addDiscoveredGVR(discoveredGVR)
 		}
+		h.detector.mu.Unlock()
 	}
 }
 
-// extractGVRFromEvent implements business logic for GVR extraction
-func (h *WorkloadResourceHandler) extractGVRFromEvent(involvedObj map[string]interface{}) string {
-	apiVersion, ok1 := involvedObj["apiVersion"].(string)
-	kind, ok2 := involvedObj["kind"].(string)
-	
-	if !ok1 || !ok2 {
-		return ""
-	}
-	
-	// CORRECTED: The Kubernetes API provides correct resource information
-	// We don't need special cases - the API is always correct
-	// Simple pluralization works for most cases, and if it doesn't,
-	// the API server will reject invalid GVRs anyway
-	resource := strings.ToLower(kind) + "s"
-	
-	return apiVersion + "/" + resource
-}
-
 // addDiscoveredGVR uses Faro mechanisms to add dynamically discovered resources
 func (h *WorkloadResourceHandler) addDiscoveredGVR(gvr string) {
 	workloadInfo := h.detector.detectedWorkloads[h.workloadID]
@@ -95,6 +95,7 @@ func main() {
 			logger.Warning("main", "Graceful shutdown timeout exceeded, forcing exit")
 		case <-sigChan:
 			logger.Warning("main", "Second signal received, forcing immediate exit")
+			logger.Shutdown() // os.Exit skips the deferred Shutdown; persist what was exported
 			os.Exit(1)
 		}
 	}
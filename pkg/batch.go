@@ -0,0 +1,139 @@
+package faro
+
+import (
+	"sync"
+	"time"
+)
+
+// Batch defaults used when BatchSize/BatchIntervalMs are unset
+const (
+	defaultBatchSize     = 100
+	defaultBatchInterval = time.Second
+)
+
+// BatchEventHandler receives matched events in batches, e.g. for bulk database inserts
+type BatchEventHandler interface {
+	OnBatch(events []MatchedEvent) error
+}
+
+// eventBatcher accumulates matched events for one BatchEventHandler and flushes them
+// once BatchSize events are pending or BatchIntervalMs has passed since the first one.
+// DELETED events flush immediately so a sink never holds a stale UID for a deleted object.
+// Batches are delivered in order on a dedicated goroutine; b.mu is released before a batch is
+// handed over, so a slow handler only holds up the Add calls that flush.
+type eventBatcher struct {
+	handler  BatchEventHandler
+	size     int
+	interval time.Duration
+	onDone   func(duration time.Duration, err error) // Called after every OnBatch
+
+	mu      sync.Mutex
+	pending []MatchedEvent
+	timer   *time.Timer
+	stopped bool
+
+	sendMu sync.Mutex // Taken before b.mu is released, keeps batches in flush order
+
+	batches chan []MatchedEvent
+	done    chan struct{}
+}
+
+// newEventBatcher creates a batcher and starts its delivery goroutine
+func newEventBatcher(handler BatchEventHandler, size int, interval time.Duration, onDone func(duration time.Duration, err error)) *eventBatcher {
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+	if interval <= 0 {
+		interval = defaultBatchInterval
+	}
+
+	b := &eventBatcher{
+		handler:  handler,
+		size:     size,
+		interval: interval,
+		onDone:   onDone,
+		batches:  make(chan []MatchedEvent, 16),
+		done:     make(chan struct{}),
+	}
+	go b.deliver()
+	return b
+}
+
+// Add queues an event, flushing when the batch is full or the event is a deletion
+func (b *eventBatcher) Add(event MatchedEvent) {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+
+	b.pending = append(b.pending, event)
+	if len(b.pending) >= b.size || event.EventType == "DELETED" || event.EventType == EventTypeEphemeral {
+		b.flushAndUnlock()
+		return
+	}
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.interval, b.flushOnInterval)
+	}
+	b.mu.Unlock()
+}
+
+// flushOnInterval flushes whatever is pending when the interval elapses
+func (b *eventBatcher) flushOnInterval() {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	b.flushAndUnlock()
+}
+
+// flushAndUnlock takes the pending events, releases b.mu and hands them to the delivery
+// goroutine; b.mu must be held. Events added meanwhile go into the next batch.
+func (b *eventBatcher) flushAndUnlock() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	if len(batch) == 0 {
+		b.mu.Unlock()
+		return
+	}
+
+	b.sendMu.Lock()
+	b.mu.Unlock()
+	b.batches <- batch
+	b.sendMu.Unlock()
+}
+
+// deliver calls the handler for each batch until the batcher is stopped
+func (b *eventBatcher) deliver() {
+	defer close(b.done)
+	for batch := range b.batches {
+		started := time.Now()
+		err := b.handler.OnBatch(batch)
+		if b.onDone != nil {
+			b.onDone(time.Since(started), err)
+		}
+	}
+}
+
+// Stop flushes pending events and waits until every batch has been delivered
+func (b *eventBatcher) Stop() {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	b.stopped = true
+	b.flushAndUnlock()
+
+	// Wait for flushes already handing over a batch before closing
+	b.sendMu.Lock()
+	close(b.batches)
+	b.sendMu.Unlock()
+
+	<-b.done
+}
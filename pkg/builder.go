@@ -0,0 +1,69 @@
+package faro
+
+// Builder helpers for assembling resource-centric configs in code.
+// They return plain ResourceConfig/Config values, so the structs can still be
+// edited directly for anything the helpers don't cover.
+//
+//	config := faro.NewConfig("./output").Add(
+//		faro.WatchResource("v1/configmaps").InNamespaces("prod").WithLabels("app=web"),
+//		faro.WatchResource("v1/namespaces").Cluster(),
+//	)
+
+// WatchResource starts a namespace-scoped ResourceConfig for gvr
+func WatchResource(gvr string) ResourceConfig {
+	return ResourceConfig{
+		GVR:   gvr,
+		Scope: NamespaceScope,
+	}
+}
+
+// InNamespaces limits the resource to the given namespaces (all namespaces if none are set)
+func (r ResourceConfig) InNamespaces(namespaces ...string) ResourceConfig {
+	r.NamespaceNames = append(append([]string(nil), r.NamespaceNames...), namespaces...)
+	return r
+}
+
+// InLabeledNamespaces watches the resource in every namespace matching the label selector
+func (r ResourceConfig) InLabeledNamespaces(selector string) ResourceConfig {
+	r.NamespaceLabelSelector = selector
+	return r
+}
+
+// OnNode limits Pods to those scheduled on node, e.g. OnNode(os.Getenv("NODE_NAME")) when
+// running as a DaemonSet. Only valid for v1/pods.
+func (r ResourceConfig) OnNode(node string) ResourceConfig {
+	r.NodeName = node
+	return r
+}
+
+// WithLabels sets the Kubernetes label selector
+func (r ResourceConfig) WithLabels(selector string) ResourceConfig {
+	r.LabelSelector = selector
+	return r
+}
+
+// Named sets the exact resource name to watch
+func (r ResourceConfig) Named(name string) ResourceConfig {
+	r.NameSelector = name
+	return r
+}
+
+// Cluster marks the resource as cluster-scoped
+func (r ResourceConfig) Cluster() ResourceConfig {
+	r.Scope = ClusterScope
+	return r
+}
+
+// NewConfig creates a Config with the CLI defaults and the given output directory
+func NewConfig(outputDir string) *Config {
+	return &Config{
+		OutputDir: outputDir,
+		LogLevel:  "info",
+	}
+}
+
+// Add appends resource configs and returns the config for chaining
+func (c *Config) Add(resources ...ResourceConfig) *Config {
+	c.Resources = append(c.Resources, resources...)
+	return c
+}
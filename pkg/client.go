@@ -1,10 +1,16 @@
 package faro
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
@@ -18,24 +24,156 @@ type KubernetesClient struct {
 	Config    *rest.Config
 }
 
+// DynamicClient is the dynamic client informers are built from; any dynamic.Interface,
+// including k8s.io/client-go/dynamic/fake, satisfies it
+type DynamicClient interface {
+	Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface
+}
+
+// DiscoveryLister is the part of API discovery the controller uses; any
+// discovery.DiscoveryInterface, including k8s.io/client-go/discovery/fake, satisfies it
+type DiscoveryLister interface {
+	ServerGroups() (*metav1.APIGroupList, error)
+	ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error)
+}
+
+// ClusterClient supplies the clients a Controller talks to the cluster through
+type ClusterClient interface {
+	DynamicClient() DynamicClient
+	DiscoveryLister() DiscoveryLister
+}
+
+// DynamicClient returns the dynamic client
+func (k *KubernetesClient) DynamicClient() DynamicClient {
+	return k.Dynamic
+}
+
+// DiscoveryLister returns the discovery client
+func (k *KubernetesClient) DiscoveryLister() DiscoveryLister {
+	return k.Discovery
+}
+
+// ClientOptions tunes the REST config used by the Kubernetes clients.
+// Zero values keep client-go defaults.
+type ClientOptions struct {
+	QPS       float32       // Sustained client-side request rate limit (client-go default: 5)
+	Burst     int           // Maximum request burst above QPS (client-go default: 10)
+	Timeout   time.Duration // Per-request timeout for everything but watches (default: none)
+	UserAgent string        // User-Agent header sent to the API server
+}
+
 // NewKubernetesClient creates a Kubernetes client
 // Automatically detects in-cluster config (when running as an operator)
 // and falls back to kubeconfig file for out-of-cluster usage
 func NewKubernetesClient() (*KubernetesClient, error) {
+	return NewKubernetesClientWithConfig(ClientOptions{})
+}
+
+// NewKubernetesClientWithConfig creates a Kubernetes client with tuned rate limits,
+// timeout and user agent. Useful on busy clusters where default QPS throttles the
+// initial lists of many informers.
+func NewKubernetesClientWithConfig(opts ClientOptions) (*KubernetesClient, error) {
+	config, err := loadRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	applyClientOptions(config, opts)
+	return newKubernetesClientForConfig(config)
+}
+
+// NewKubernetesClientFromKubeconfig creates a Kubernetes client from an explicit kubeconfig
+// file, skipping in-cluster detection. An empty path uses $KUBECONFIG or ~/.kube/config.
+func NewKubernetesClientFromKubeconfig(path string) (*KubernetesClient, error) {
+	if path == "" {
+		path = defaultKubeconfigPath()
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubeconfig from %s: %w", path, err)
+	}
+	return newKubernetesClientForConfig(config)
+}
+
+// loadRESTConfig tries in-cluster config first, then the kubeconfig file
+func loadRESTConfig() (*rest.Config, error) {
 	// Try in-cluster config first (for operator deployments)
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		// Fallback to kubeconfig file (for CLI/local usage)
-		kubeconfigPath := os.Getenv("KUBECONFIG")
-		if kubeconfigPath == "" {
-			kubeconfigPath = filepath.Join(os.Getenv("HOME"), ".kube", "config")
-		}
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+		config, err = clientcmd.BuildConfigFromFlags("", defaultKubeconfigPath())
 		if err != nil {
 			return nil, fmt.Errorf("failed to build kubeconfig: %w", err)
 		}
 	}
+	return config, nil
+}
+
+// defaultKubeconfigPath returns $KUBECONFIG, falling back to ~/.kube/config
+func defaultKubeconfigPath() string {
+	if kubeconfigPath := os.Getenv("KUBECONFIG"); kubeconfigPath != "" {
+		return kubeconfigPath
+	}
+	return filepath.Join(os.Getenv("HOME"), ".kube", "config")
+}
+
+// applyClientOptions copies the non-zero options onto the REST config
+func applyClientOptions(config *rest.Config, opts ClientOptions) {
+	if opts.QPS > 0 {
+		config.QPS = opts.QPS
+	}
+	if opts.Burst > 0 {
+		config.Burst = opts.Burst
+	}
+	if opts.Timeout > 0 {
+		// Not rest.Config.Timeout: that also cuts every long-running watch, forcing
+		// constant re-watches and relists
+		timeout := opts.Timeout
+		config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &nonWatchTimeoutRoundTripper{rt: rt, timeout: timeout}
+		})
+	}
+	if opts.UserAgent != "" {
+		config.UserAgent = opts.UserAgent
+	}
+}
+
+// nonWatchTimeoutRoundTripper bounds every request except watches by timeout, including
+// reading the response body
+type nonWatchTimeoutRoundTripper struct {
+	rt      http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *nonWatchTimeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Query().Get("watch") == "true" {
+		return t.rt.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the request's timeout context once the body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
 
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// newKubernetesClientForConfig builds the dynamic and discovery clients from a REST config
+func newKubernetesClientForConfig(config *rest.Config) (*KubernetesClient, error) {
 	// Create dynamic client
 	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
@@ -56,4 +194,3 @@ func NewKubernetesClient() (*KubernetesClient, error) {
 
 	return client, nil
 }
-
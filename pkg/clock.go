@@ -0,0 +1,16 @@
+package faro
+
+import "time"
+
+// Clock tells the controller the current time. Controller.SetClock replaces the real clock,
+// e.g. with a fixed one so tests get deterministic processedAt and event timestamps.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
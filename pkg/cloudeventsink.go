@@ -0,0 +1,120 @@
+package faro
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cloudEventsSpecVersion is the CloudEvents version of the envelopes a CloudEventsSink writes
+const cloudEventsSpecVersion = "1.0"
+
+// CloudEvent is a CloudEvents 1.0 envelope in structured JSON mode, carrying a JSONEvent as data
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            string    `json:"time,omitempty"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            JSONEvent `json:"data"`
+}
+
+// CloudEventsSink is an EventHandler writing matched events as CloudEvents 1.0 JSON lines, for
+// Knative, Argo Events and other event-mesh consumers. Envelopes have type dev.faro.<eventType>
+// (lowercased, e.g. dev.faro.added), source /faro/<cluster>/<gvr> and subject <namespace>/<name>.
+// The id is built from the object's UID, resourceVersion and event type, so a consumer can drop
+// redeliveries of the same event.
+type CloudEventsSink struct {
+	file    *os.File
+	cluster string
+	mu      sync.Mutex
+}
+
+// NewCloudEventsSink creates a sink appending envelopes to path. cluster names the cluster in
+// the source attribute ("" = "default").
+func NewCloudEventsSink(path, cluster string) (*CloudEventsSink, error) {
+	if cluster == "" {
+		cluster = "default"
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CloudEvents sink file: %w", err)
+	}
+	return &CloudEventsSink{file: file, cluster: cluster}, nil
+}
+
+// Name labels the sink's handler metrics
+func (s *CloudEventsSink) Name() string {
+	return "cloudevents:" + s.file.Name()
+}
+
+// ReadOnlyEvents reports that the sink never modifies event.Object
+func (s *CloudEventsSink) ReadOnlyEvents() bool {
+	return true
+}
+
+// OnMatched wraps the event in a CloudEvents envelope and appends it to the file as one line
+func (s *CloudEventsSink) OnMatched(event MatchedEvent) error {
+	line, err := json.Marshal(s.envelope(event))
+	if err != nil {
+		return fmt.Errorf("failed to encode CloudEvent: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write CloudEvent: %w", err)
+	}
+	return nil
+}
+
+// envelope builds the CloudEvent for a matched event
+func (s *CloudEventsSink) envelope(event MatchedEvent) CloudEvent {
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	data := JSONEvent{
+		Timestamp: timestamp.UTC().Format(time.RFC3339),
+		EventType: event.EventType,
+		GVR:       event.GVR,
+		ConfigID:  event.Config.ConfigID,
+	}
+	id := fmt.Sprintf("%s-%d", event.EventType, timestamp.UnixNano())
+	if event.Object != nil {
+		data.Namespace = event.Object.GetNamespace()
+		data.Name = event.Object.GetName()
+		data.UID = string(event.Object.GetUID())
+		data.Labels = event.Object.GetLabels()
+		if data.UID != "" {
+			id = fmt.Sprintf("%s-%s-%s", data.UID, event.Object.GetResourceVersion(), event.EventType)
+		}
+	}
+
+	subject := data.Name
+	if data.Namespace != "" {
+		subject = data.Namespace + "/" + data.Name
+	}
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              id,
+		Source:          "/faro/" + s.cluster + "/" + event.GVR,
+		Type:            "dev.faro." + strings.ToLower(event.EventType),
+		Subject:         subject,
+		Time:            timestamp.UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// Close closes the underlying file
+func (s *CloudEventsSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -5,8 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Scope defines whether a resource is cluster-scoped or namespace-scoped
@@ -17,6 +22,33 @@ const (
 	NamespaceScope  Scope = "Namespaced"
 )
 
+// Configuration formats a NormalizedConfig was converted from (NormalizedConfig.ConfigFormat)
+const (
+	ConfigFormatNamespace = "namespace" // Namespace-centric "namespaces" entries
+	ConfigFormatResource  = "resource"  // Resource-centric "resources" entries
+)
+
+// JSON event timestamp sources (Config.JsonTimestampSource)
+const (
+	JsonTimestampCreation   = "creation"   // creationTimestamp for ADDED/UPDATED, processing time for DELETED (default)
+	JsonTimestampProcessing = "processing" // Time Faro processed the event, for every event type
+	JsonTimestampBoth       = "both"       // "creation" semantics plus a separate processedAt field
+)
+
+// Epoch formats for JSON event timestamps (Config.JsonTimeFormat); any other value is a Go time layout
+const (
+	JsonTimeFormatUnix      = "unix"      // Seconds since the epoch
+	JsonTimeFormatUnixMilli = "unixmilli" // Milliseconds since the epoch
+)
+
+// Handling of DELETED events whose UID is neither cached nor carried by the deleted object
+// (Config.DeleteUIDPolicy)
+const (
+	DeleteUIDPolicyRequire    = "require"     // Drop the event with an error (default)
+	DeleteUIDPolicyUnknown    = "unknown"     // Emit the event with uid "unknown"
+	DeleteUIDPolicyBestEffort = "best-effort" // Use the UID of a still finalizing object from an API GET, "unknown" if that fails
+)
+
 // ResourceDetails defines what resources to watch within a namespace (legacy format)
 type ResourceDetails struct {
 	LabelSelector string `yaml:"label_selector,omitempty"` // Kubernetes label selector for SERVER-SIDE filtering only (e.g. "app=faro-test")
@@ -35,16 +67,36 @@ type ResourceConfig struct {
 	NamespaceNames []string `yaml:"namespace_names,omitempty"` // Exact namespace names only (for server-side filtering)
 	NameSelector   string   `yaml:"name_selector,omitempty"`   // Exact name for resource name filtering (server-side)
 	LabelSelector  string   `yaml:"label_selector,omitempty"`  // Kubernetes label selector for SERVER-SIDE filtering only (e.g. "app=faro-test")
+	LabelSelectors []string `yaml:"label_selectors,omitempty"` // OR-ed label selectors, one informer each; objects matching several are reported once
+	SampleRate     float64  `yaml:"sample_rate,omitempty"`     // Fraction of ADDED/UPDATED events to keep; 0 is the same as unset and keeps ALL events, not none (1 = keep all too); DELETED is always kept
+	MaxEventsPerSec float64 `yaml:"max_events_per_sec,omitempty"` // Drop ADDED/UPDATED events above this rate for the GVR (0 = unlimited); DELETED is always kept
+	Priority       int      `yaml:"priority,omitempty"`        // Informer start order, lowest first (see PriorityWaitForSync)
+	JSONPathSelectors map[string]string `yaml:"jsonpath_selectors,omitempty"` // Dotted field path -> required value (e.g. spec.type: LoadBalancer), CLIENT-SIDE; all must match
+	ConfigID       string   `yaml:"config_id,omitempty"`       // User-assigned identity, reported on matched and exported events
+	PreferredVersion bool   `yaml:"preferred_version,omitempty"` // Watch the group's preferred version from discovery; the GVR's version is ignored ("example.com//widgets")
+	NamespaceLabelSelector string `yaml:"namespace_label_selector,omitempty"` // Watch the GVR in every namespace whose labels match, following namespaces as they come and go
+	NodeName       string   `yaml:"node_name,omitempty"`       // Only Pods scheduled on this node (spec.nodeName field selector); $VAR references are expanded, e.g. "$NODE_NAME"
 }
 
 // NormalizedConfig is the unified data structure used internally by the controller.
 // This represents the normalized form that both configuration formats are converted to.
 type NormalizedConfig struct {
-	GVR               string          // Group/Version/Resource identifier
-	ResourceDetails   ResourceDetails // Resource matching details (SERVER-SIDE only)
-	NamespaceNames []string        // Literal namespace names only (for server-side filtering)
-	NameSelector   string          // Exact name for resource name filtering (server-side)
-	LabelSelector     string          // Kubernetes label selector for SERVER-SIDE filtering only (e.g. "app=faro-test")
+	GVR               string          `json:"gvr"`                     // Group/Version/Resource identifier
+	ResourceDetails   ResourceDetails `json:"-"`                       // Resource matching details (SERVER-SIDE only)
+	NamespaceNames []string        `json:"namespaceNames,omitempty"` // Literal namespace names only (for server-side filtering)
+	NameSelector   string          `json:"nameSelector,omitempty"`   // Exact name for resource name filtering (server-side)
+	LabelSelector     string          `json:"labelSelector,omitempty"` // Kubernetes label selector for SERVER-SIDE filtering only (e.g. "app=faro-test")
+	LabelSelectors    []string        `json:"labelSelectors,omitempty"` // OR-ed label selectors, one informer each
+	SampleRate        float64         `json:"sampleRate,omitempty"`    // Fraction of ADDED/UPDATED events to keep (0 = unset, keep all)
+	MaxEventsPerSec   float64         `json:"maxEventsPerSec,omitempty"` // ADDED/UPDATED events kept per second for the GVR (0 = unlimited)
+	Priority          int             `json:"priority,omitempty"`      // Informer start order, lowest first
+	JSONPathSelectors map[string]string `json:"jsonPathSelectors,omitempty"` // Dotted field path -> required value, evaluated client-side
+	Scope             Scope           `json:"scope,omitempty"`         // Declared scope, checked against discovery ("" = not declared)
+	ConfigFormat      string          `json:"configFormat,omitempty"`  // ConfigFormatNamespace or ConfigFormatResource
+	ConfigID          string          `json:"configId,omitempty"`      // User-assigned ResourceConfig.ConfigID
+	PreferredVersion  bool            `json:"preferredVersion,omitempty"` // GVR version is resolved from discovery
+	NamespaceLabelSelector string `json:"namespaceLabelSelector,omitempty"` // Namespaces are selected by label instead of NamespaceNames
+	NodeName          string          `json:"nodeName,omitempty"`      // Pods are selected by spec.nodeName (environment references expanded)
 }
 
 // MetricsConfig defines Prometheus metrics configuration
@@ -53,6 +105,11 @@ type MetricsConfig struct {
 	Port       int    `yaml:"port"`                 // Port for metrics HTTP server (default: 8080)
 	Path       string `yaml:"path"`                 // Metrics endpoint path (default: /metrics)
 	BindAddr   string `yaml:"bind_addr"`            // Bind address (default: 0.0.0.0)
+	RedactConfigSelectors bool `yaml:"redact_config_selectors,omitempty"` // Hide label selectors on the /config endpoint
+	TLSCertFile       string `yaml:"tls_cert_file,omitempty"`       // Serve HTTPS with this certificate (requires tls_key_file)
+	TLSKeyFile        string `yaml:"tls_key_file,omitempty"`        // Private key for tls_cert_file
+	BasicAuthUser     string `yaml:"basic_auth_user,omitempty"`     // Require HTTP basic auth on the metrics and /config paths (requires basic_auth_password)
+	BasicAuthPassword string `yaml:"basic_auth_password,omitempty"` // Password for basic_auth_user
 }
 
 // Config represents the minimalist Faro configuration supporting both formats
@@ -61,11 +118,94 @@ type Config struct {
 	LogLevel        string            `yaml:"log_level"`        // Log level: debug, info, warning, error, fatal
 	AutoShutdownSec int               `yaml:"auto_shutdown_sec"` // Auto-shutdown timeout in seconds (0 = run indefinitely)
 	JsonExport      bool              `yaml:"json_export,omitempty"` // Enable JSON event export to separate file
+	JsonLabelKeys      []string `yaml:"json_label_keys,omitempty"`      // Only export these label keys in JSON events (empty = all)
+	JsonAnnotationKeys []string `yaml:"json_annotation_keys,omitempty"` // Only export these annotation keys in JSON events (empty = all)
+	JsonComponents     []string `yaml:"json_components,omitempty"`      // Extra logger components whose JSON messages reach the export (controller and cluster-handler always do)
+	JsonIncludeObject      bool `yaml:"json_include_object,omitempty"`       // Add the full object body (after middleware) to JSON events
+	JsonStripManagedFields bool `yaml:"json_strip_managed_fields,omitempty"` // Drop metadata.managedFields from exported object bodies
+	JsonIncludeOwners      bool `yaml:"json_include_owners,omitempty"`       // Add the owner chain (resolved from informer caches) to JSON events
+	JsonIncludeKind        bool `yaml:"json_include_kind,omitempty"`         // Add the Kind (from API discovery) to JSON events
+	JsonTimestampSource string `yaml:"json_timestamp_source,omitempty"` // "creation" (default), "processing" or "both" - see JsonTimestamp* constants
+	JsonTimeFormat      string `yaml:"json_time_format,omitempty"`      // Go time layout (in UTC) or "unix"/"unixmilli" for JSON event timestamps (empty = RFC3339Nano)
+	JsonIndexIntervalMs int    `yaml:"json_index_interval_ms,omitempty"` // Write an offset/time entry to events-*.json.idx at most this often, for SeekEvents (0 = no index)
+	JsonErrorExport     bool   `yaml:"json_error_export,omitempty"`      // Write Faro's own error records (watch errors, reconcile failures, dropped items) to errors-*.json
+	JsonRotateSizeMB    int    `yaml:"json_rotate_size_mb,omitempty"`    // Close the JSON export segment at this size and continue in a fresh file (0 = never rotate)
+	JsonCompressRotated bool   `yaml:"json_compress_rotated,omitempty"`  // Gzip closed JSON export segments to events-*.json.gz in the background
+	JsonPartitionBy     string `yaml:"json_partition_by,omitempty"`      // Label key splitting the export into events-<value>-<timestamp>.json files (empty = one file)
 	Metrics         MetricsConfig     `yaml:"metrics,omitempty"`     // Prometheus metrics configuration
 	
+	UIDCacheMaxEntries int `yaml:"uid_cache_max_entries,omitempty"` // Per-informer UID cache cap with LRU eviction (0 = unbounded)
+	DeleteUIDPolicy    string `yaml:"delete_uid_policy,omitempty"` // DELETED event without a cached UID or one on the deleted object: "require" (default, dropped), "unknown" or "best-effort" - see DeleteUIDPolicy* constants
+	SuppressInitialAdds bool `yaml:"suppress_initial_adds,omitempty"` // Don't emit ADDED for objects in an informer's initial list - only changes after it
+	EmitSyncEvents      bool `yaml:"emit_sync_events,omitempty"`      // Emit a SYNCED event (no object) per informer once its initial list is queued
+	DedupAcrossResync   bool `yaml:"dedup_across_resync,omitempty"`   // After an informer restart, drop ADDED for already reported objects (UPDATED if changed meanwhile)
+	ResyncPeriodSec     int  `yaml:"resync_period_sec,omitempty"`     // Redeliver every cached object as UPDATED about this often, jittered per informer by up to 10% (0 = no resync)
+	
+	// Event deduplication
+	DedupWindowMs int    `yaml:"dedup_window_ms,omitempty"` // Collapse events for the same object within this window (0 = disabled)
+	DedupMode     string `yaml:"dedup_mode,omitempty"`      // "trailing" (emit latest when window closes, default) or "leading" (emit first)
+	CoalesceShortLived bool   `yaml:"coalesce_short_lived,omitempty"` // Objects deleted while their ADDED is still in the (trailing) dedup window yield one event instead of ADDED+DELETED
+	ShortLivedMode     string `yaml:"short_lived_mode,omitempty"`     // "ephemeral" (one EPHEMERAL event, default) or "drop" (no event) for coalesced objects
+	
+	// Update loop protection
+	IgnoreSelfInducedChanges bool   `yaml:"ignore_self_induced_changes,omitempty"` // Drop UPDATED events whose latest managedFields entry belongs to SelfFieldManager
+	SelfFieldManager         string `yaml:"self_field_manager,omitempty"`          // Field manager name the handlers write objects with
+	
+	// Event delivery (EventHandler)
+	HandlerQueueSize   int    `yaml:"handler_queue_size,omitempty"`   // Buffer up to this many events per handler, delivered by one goroutine each (0 = a goroutine per event)
+	HandlerQueuePolicy string `yaml:"handler_queue_policy,omitempty"` // Full queue: "drop" (default, counted) or "block" the worker - see HandlerQueuePolicy* constants
+	MaxHandlerConcurrency    int    `yaml:"max_handler_concurrency,omitempty"`    // Limit concurrent OnMatched calls of unqueued handlers across all events (0 = unlimited)
+	HandlerConcurrencyPolicy string `yaml:"handler_concurrency_policy,omitempty"` // All slots busy: "drop" (default, counted) or "block" the worker - see HandlerConcurrencyPolicy* constants
+	HandlerTimeoutSec        int    `yaml:"handler_timeout_sec,omitempty"`        // Cancel the context of ContextEventHandler calls after this many seconds (0 = no timeout)
+	EventRingSize            int    `yaml:"event_ring_size,omitempty"`            // Keep the last this many matched events in memory for Controller.RecentEvents (0 = disabled)
+	
+	// Event batching (BatchEventHandler)
+	BatchSize       int `yaml:"batch_size,omitempty"`        // Deliver batches once this many events are pending (0 = default 100)
+	BatchIntervalMs int `yaml:"batch_interval_ms,omitempty"` // Deliver a partial batch after this many milliseconds (0 = default 1000)
+	
+	// Discovery
+	DiscoveryTimeoutSec int  `yaml:"discovery_timeout_sec,omitempty"` // Abort an API discovery attempt in Start after this many seconds (0 = no timeout)
+	DiscoveryRetries    int  `yaml:"discovery_retries,omitempty"`     // Retry failed API discovery in Start this many times before giving up (0 = fail at once)
+	DiscoveryRetryBackoffMs int `yaml:"discovery_retry_backoff_ms,omitempty"` // Wait before the first discovery retry, doubled per retry up to 30s (0 = default 1000)
+	DiscoveryWorkers    int  `yaml:"discovery_workers,omitempty"`     // Concurrent group/version discovery calls (0 = default 10)
+	WatchCRDs           bool `yaml:"watch_crds,omitempty"`            // Start informers for configured GVRs served by CRDs created after Start
+	CRDReAddDebounceSec int  `yaml:"crd_readd_debounce_sec,omitempty"` // With WatchCRDs, keep a deleted CRD's informers this many seconds in case it is re-added (0 = stop at once)
+	RequireCoreGroup    *bool `yaml:"require_core_group,omitempty"`   // Fail Start() when core ("v1") group discovery fails (default: true)
+	APIServiceRetrySec  int  `yaml:"api_service_retry_sec,omitempty"` // Retry discovery of unavailable group versions (e.g. aggregated APIs) this often (0 = default 30)
+	
+	// Informer circuit breaker
+	MaxInformerRestarts      int `yaml:"max_informer_restarts,omitempty"`       // Stop an informer after this many list/watch failures within the window (0 = retry forever)
+	InformerRestartWindowSec int `yaml:"informer_restart_window_sec,omitempty"` // Window for counting informer failures (0 = default 300)
+	
+	// Work queue
+	MaxReconcileRetries int `yaml:"max_reconcile_retries,omitempty"` // Drop a work item after it failed this many retries (0 = retry forever)
+	
+	// Informer listing
+	InformerListPageSize int64 `yaml:"informer_list_page_size,omitempty"` // Fetch the initial list in pages of this many objects (0 = client-go default)
+	FastStart            bool  `yaml:"fast_start,omitempty"`              // List with resourceVersion "0" (API server watch cache), trading freshness for cheaper lists
+	UseBookmarks         bool  `yaml:"use_bookmarks,omitempty"`           // Request watch bookmarks so reconnects resume from a recent resourceVersion instead of relisting
+	
+	// Logging
+	LogDedupWindowSec int `yaml:"log_dedup_window_sec,omitempty"` // Log identical errors once per window with a suppressed count (0 = log every error)
+	KlogVerbosity     *int `yaml:"klog_verbosity,omitempty"`     // klog -v for client-go internals, independent of log_level (unset = 1 at debug level, else 0)
+	LogPrefix         string `yaml:"log_prefix,omitempty"`    // Tag for this controller's log lines, error records and JSON events (e.g. the cluster name)
+	
+	// Shutdown
+	StopTimeoutSec int `yaml:"stop_timeout_sec,omitempty"` // Return from Stop after this many seconds even if goroutines are still running (0 = wait indefinitely)
+	
+	// Startup checks
+	PriorityWaitForSync    bool `yaml:"priority_wait_for_sync,omitempty"`    // At Start, start a resource priority only once all lower priorities have synced
+	PrioritySyncTimeoutSec int  `yaml:"priority_sync_timeout_sec,omitempty"` // With PriorityWaitForSync, start the next priority anyway after this many seconds (0 = default 60)
+	PreflightRBACCheck     bool `yaml:"preflight_rbac_check,omitempty"`      // Verify list access for each configured GVR+namespace before starting informers
+	PreflightRBACFailFast  bool `yaml:"preflight_rbac_fail_fast,omitempty"`  // Fail Start() when the preflight check finds denied GVRs (default: log warnings only)
+	FailOnScopeMismatch    bool `yaml:"fail_on_scope_mismatch,omitempty"`    // Fail Start() when a resource's scope differs from discovery (default: log a warning)
+	
 	// Simple configuration formats
 	Namespaces      []NamespaceConfig `yaml:"namespaces,omitempty"`  // Simple namespace format
 	Resources       []ResourceConfig  `yaml:"resources,omitempty"`   // Simple resource format
+	
+	// Additional files whose namespaces/resources are merged in, relative to the including file
+	Include         []string          `yaml:"include,omitempty"`
 }
 
 // LoadConfig loads configuration from YAML file or command line arguments
@@ -79,7 +219,9 @@ func LoadConfigWithVersion(version, commit, date, builtBy string) (*Config, erro
 	
 	// Define command line flags
 	var configFile string
+	var configDir string
 	flag.StringVar(&configFile, "config", "", "Path to YAML configuration file")
+	flag.StringVar(&configDir, "config-dir", "", "Directory of YAML configuration files to merge (in lexical order)")
 	flag.StringVar(&config.OutputDir, "output-dir", "./output", "Directory for output files and logs")
 	flag.StringVar(&config.LogLevel, "log-level", "info", "Log level (debug, info, warning, error, fatal)")
 	flag.IntVar(&config.AutoShutdownSec, "auto-shutdown", 0, "Auto-shutdown timeout in seconds (0 = run indefinitely)")
@@ -116,6 +258,13 @@ func LoadConfigWithVersion(version, commit, date, builtBy string) (*Config, erro
 		}
 	}
 	
+	// Merge a directory of YAML files if specified
+	if configDir != "" {
+		if err := config.LoadFromDirectory(configDir); err != nil {
+			return nil, fmt.Errorf("failed to load config directory: %w", err)
+		}
+	}
+	
 	// Validate configuration
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -129,20 +278,119 @@ func LoadConfigWithVersion(version, commit, date, builtBy string) (*Config, erro
 	return config, nil
 }
 
-// LoadFromYAML loads configuration from a YAML file
+// LoadFromYAML loads configuration from a YAML file, merging the namespaces and
+// resources of any files listed under include
 func (c *Config) LoadFromYAML(filename string) error {
+	return c.loadFile(filename, make(map[string]string), make(map[string]bool))
+}
+
+// LoadFromDirectory loads every *.yaml/*.yml file in dir in lexical order. Settings in
+// later files override earlier ones; namespaces and resources from all files are merged.
+// A GVR defined in more than one file is rejected.
+func (c *Config) LoadFromDirectory(dir string) error {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return fmt.Errorf("failed to list config directory: %w", err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no YAML files found in %s", dir)
+	}
+	sort.Strings(files)
+
+	sources := make(map[string]string)
+	visited := make(map[string]bool)
+	for _, file := range files {
+		if err := c.loadFile(file, sources, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadFile unmarshals filename over c and appends its namespaces and resources (and those
+// of its includes) to the ones already loaded. sources maps each GVR entry to the file that
+// defined it so duplicates across files can be reported.
+func (c *Config) loadFile(filename string, sources map[string]string, visited map[string]bool) error {
+	absPath, err := filepath.Abs(filename)
+	if err != nil {
+		return fmt.Errorf("invalid config file path: %w", err)
+	}
+	if visited[absPath] {
+		return fmt.Errorf("config file %s is loaded more than once (include cycle?)", filename)
+	}
+	visited[absPath] = true
+
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 	
+	// Unmarshal over c so settings accumulate, but collect lists separately for merging
+	existingNamespaces, existingResources := c.Namespaces, c.Resources
+	c.Namespaces, c.Resources, c.Include = nil, nil, nil
 	if err := yaml.Unmarshal(data, c); err != nil {
-		return fmt.Errorf("failed to parse YAML config: %w", err)
+		return fmt.Errorf("failed to parse YAML config %s: %w", filename, err)
+	}
+	loaded := &Config{Namespaces: c.Namespaces, Resources: c.Resources}
+	includes := c.Include
+	c.Namespaces, c.Resources, c.Include = existingNamespaces, existingResources, nil
+
+	if err := c.mergeResources(loaded, filename, sources); err != nil {
+		return err
+	}
+
+	// Included files only contribute namespaces and resources
+	for _, include := range includes {
+		includePath := include
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(filename), include)
+		}
+		included := &Config{}
+		if err := included.loadFile(includePath, sources, visited); err != nil {
+			return fmt.Errorf("failed to load include %s: %w", include, err)
+		}
+		if err := c.mergeResources(included, includePath, nil); err != nil {
+			return err
+		}
 	}
 	
 	return nil
 }
 
+// mergeResources appends other's namespaces and resources to c. When sources is non-nil,
+// GVR entries already defined by a different file are rejected.
+func (c *Config) mergeResources(other *Config, filename string, sources map[string]string) error {
+	if sources != nil {
+		claim := func(key, description string) error {
+			if previous, exists := sources[key]; exists && previous != filename {
+				return fmt.Errorf("duplicate %s in %s (already defined in %s)", description, filename, previous)
+			}
+			sources[key] = filename
+			return nil
+		}
+		for _, resConfig := range other.Resources {
+			if err := claim("resource:"+resConfig.GVR, "GVR "+resConfig.GVR); err != nil {
+				return err
+			}
+		}
+		for _, nsConfig := range other.Namespaces {
+			for gvr := range nsConfig.Resources {
+				if err := claim("namespace:"+nsConfig.NameSelector+":"+gvr, fmt.Sprintf("GVR %s for namespace %s", gvr, nsConfig.NameSelector)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	c.Namespaces = append(c.Namespaces, other.Namespaces...)
+	c.Resources = append(c.Resources, other.Resources...)
+	return nil
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	// Validate log level
@@ -169,10 +417,207 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid output directory path: %w", err)
 	}
 	c.OutputDir = absPath
+	if err := checkDirWritable(c.OutputDir); err != nil {
+		return fmt.Errorf("output directory %s is not writable: %w", c.OutputDir, err)
+	}
+
+	// Validate circuit breaker settings
+	if c.DiscoveryRetries < 0 {
+		return fmt.Errorf("invalid discovery_retries %d, must not be negative", c.DiscoveryRetries)
+	}
+	if c.DiscoveryRetryBackoffMs < 0 {
+		return fmt.Errorf("invalid discovery_retry_backoff_ms %d, must not be negative", c.DiscoveryRetryBackoffMs)
+	}
+	if c.APIServiceRetrySec < 0 {
+		return fmt.Errorf("invalid api_service_retry_sec %d, must not be negative", c.APIServiceRetrySec)
+	}
+	if c.PrioritySyncTimeoutSec < 0 {
+		return fmt.Errorf("invalid priority_sync_timeout_sec %d, must not be negative", c.PrioritySyncTimeoutSec)
+	}
+	if c.CRDReAddDebounceSec < 0 {
+		return fmt.Errorf("invalid crd_readd_debounce_sec %d, must not be negative", c.CRDReAddDebounceSec)
+	}
+	if c.MaxInformerRestarts < 0 {
+		return fmt.Errorf("invalid max_informer_restarts %d, must not be negative", c.MaxInformerRestarts)
+	}
+	if c.InformerRestartWindowSec < 0 {
+		return fmt.Errorf("invalid informer_restart_window_sec %d, must not be negative", c.InformerRestartWindowSec)
+	}
+	if c.InformerListPageSize < 0 {
+		return fmt.Errorf("invalid informer_list_page_size %d, must not be negative", c.InformerListPageSize)
+	}
+	if c.MaxReconcileRetries < 0 {
+		return fmt.Errorf("invalid max_reconcile_retries %d, must not be negative", c.MaxReconcileRetries)
+	}
+	
+	// Validate JSON export settings
+	switch c.JsonTimestampSource {
+	case "", JsonTimestampCreation, JsonTimestampProcessing, JsonTimestampBoth:
+	default:
+		return fmt.Errorf("invalid json_timestamp_source '%s', must be one of: %s, %s, %s",
+			c.JsonTimestampSource, JsonTimestampCreation, JsonTimestampProcessing, JsonTimestampBoth)
+	}
+	// A layout without any time elements would stamp every event with the same text
+	if c.JsonTimeFormat != "" && c.JsonTimeFormat != JsonTimeFormatUnix && c.JsonTimeFormat != JsonTimeFormatUnixMilli &&
+		time.Unix(0, 0).UTC().Format(c.JsonTimeFormat) == time.Unix(1234567890, 123456789).UTC().Format(c.JsonTimeFormat) {
+		return fmt.Errorf("invalid json_time_format '%s', must be %s, %s or a Go time layout such as %s",
+			c.JsonTimeFormat, JsonTimeFormatUnix, JsonTimeFormatUnixMilli, time.RFC3339)
+	}
+	
+	switch c.DeleteUIDPolicy {
+	case "", DeleteUIDPolicyRequire, DeleteUIDPolicyUnknown, DeleteUIDPolicyBestEffort:
+	default:
+		return fmt.Errorf("invalid delete_uid_policy '%s', must be one of: %s, %s, %s",
+			c.DeleteUIDPolicy, DeleteUIDPolicyRequire, DeleteUIDPolicyUnknown, DeleteUIDPolicyBestEffort)
+	}
+	if c.JsonIndexIntervalMs < 0 {
+		return fmt.Errorf("invalid json_index_interval_ms %d, must not be negative", c.JsonIndexIntervalMs)
+	}
+	if c.JsonRotateSizeMB < 0 {
+		return fmt.Errorf("invalid json_rotate_size_mb %d, must not be negative", c.JsonRotateSizeMB)
+	}
+	// Events are partitioned by their exported labels, so the key must survive json_label_keys
+	if c.JsonPartitionBy != "" && len(c.JsonLabelKeys) > 0 && !slices.Contains(c.JsonLabelKeys, c.JsonPartitionBy) {
+		return fmt.Errorf("invalid json_partition_by '%s', must be one of json_label_keys", c.JsonPartitionBy)
+	}
+	
+	// Validate log settings
+	if c.LogDedupWindowSec < 0 {
+		return fmt.Errorf("invalid log_dedup_window_sec %d, must not be negative", c.LogDedupWindowSec)
+	}
+	if c.KlogVerbosity != nil && *c.KlogVerbosity < 0 {
+		return fmt.Errorf("invalid klog_verbosity %d, must not be negative", *c.KlogVerbosity)
+	}
+	
+	// Validate dedup settings
+	if c.DedupWindowMs < 0 {
+		return fmt.Errorf("invalid dedup_window_ms %d, must not be negative", c.DedupWindowMs)
+	}
+	if c.DedupMode != "" && c.DedupMode != DedupModeTrailing && c.DedupMode != DedupModeLeading {
+		return fmt.Errorf("invalid dedup_mode '%s', must be one of: %s, %s", c.DedupMode, DedupModeTrailing, DedupModeLeading)
+	}
+	if c.CoalesceShortLived && (c.DedupWindowMs == 0 || c.DedupMode == DedupModeLeading) {
+		return fmt.Errorf("invalid coalesce_short_lived, requires dedup_window_ms in %s dedup_mode", DedupModeTrailing)
+	}
+	if c.ShortLivedMode != "" && c.ShortLivedMode != ShortLivedModeEphemeral && c.ShortLivedMode != ShortLivedModeDrop {
+		return fmt.Errorf("invalid short_lived_mode '%s', must be one of: %s, %s", c.ShortLivedMode, ShortLivedModeEphemeral, ShortLivedModeDrop)
+	}
+	if c.IgnoreSelfInducedChanges && c.SelfFieldManager == "" {
+		return fmt.Errorf("invalid self_field_manager '', must be set with ignore_self_induced_changes")
+	}
+
+	// Validate metrics server security settings
+	if (c.Metrics.TLSCertFile == "") != (c.Metrics.TLSKeyFile == "") {
+		return fmt.Errorf("invalid metrics TLS settings, tls_cert_file and tls_key_file must be set together")
+	}
+	if (c.Metrics.BasicAuthUser == "") != (c.Metrics.BasicAuthPassword == "") {
+		return fmt.Errorf("invalid metrics basic auth settings, basic_auth_user and basic_auth_password must be set together")
+	}
+
+	// Validate handler delivery settings
+	if c.HandlerQueueSize < 0 {
+		return fmt.Errorf("invalid handler_queue_size %d, must not be negative", c.HandlerQueueSize)
+	}
+	if c.HandlerQueuePolicy != "" && c.HandlerQueuePolicy != HandlerQueuePolicyDrop && c.HandlerQueuePolicy != HandlerQueuePolicyBlock {
+		return fmt.Errorf("invalid handler_queue_policy '%s', must be one of: %s, %s", c.HandlerQueuePolicy, HandlerQueuePolicyDrop, HandlerQueuePolicyBlock)
+	}
+	if c.MaxHandlerConcurrency < 0 {
+		return fmt.Errorf("invalid max_handler_concurrency %d, must not be negative", c.MaxHandlerConcurrency)
+	}
+	if c.HandlerConcurrencyPolicy != "" && c.HandlerConcurrencyPolicy != HandlerConcurrencyPolicyDrop && c.HandlerConcurrencyPolicy != HandlerConcurrencyPolicyBlock {
+		return fmt.Errorf("invalid handler_concurrency_policy '%s', must be one of: %s, %s", c.HandlerConcurrencyPolicy, HandlerConcurrencyPolicyDrop, HandlerConcurrencyPolicyBlock)
+	}
+	if c.ResyncPeriodSec < 0 {
+		return fmt.Errorf("invalid resync_period_sec %d, must not be negative", c.ResyncPeriodSec)
+	}
+	if c.EventRingSize < 0 {
+		return fmt.Errorf("invalid event_ring_size %d, must not be negative", c.EventRingSize)
+	}
+	if c.HandlerTimeoutSec < 0 {
+		return fmt.Errorf("invalid handler_timeout_sec %d, must not be negative", c.HandlerTimeoutSec)
+	}
+
+	// Validate batch settings
+	if c.BatchSize < 0 {
+		return fmt.Errorf("invalid batch_size %d, must not be negative", c.BatchSize)
+	}
+	if c.BatchIntervalMs < 0 {
+		return fmt.Errorf("invalid batch_interval_ms %d, must not be negative", c.BatchIntervalMs)
+	}
+
+	// Validate per-resource sampling rates and selectors
+	for _, resConfig := range c.Resources {
+		if resConfig.LabelSelector != "" && len(resConfig.LabelSelectors) > 0 {
+			return fmt.Errorf("invalid selectors for %s, label_selector and label_selectors are mutually exclusive", resConfig.GVR)
+		}
+		if resConfig.NamespaceLabelSelector != "" {
+			if len(resConfig.NamespaceNames) > 0 {
+				return fmt.Errorf("invalid namespaces for %s, namespace_names and namespace_label_selector are mutually exclusive", resConfig.GVR)
+			}
+			if _, err := labels.Parse(resConfig.NamespaceLabelSelector); err != nil {
+				return fmt.Errorf("invalid namespace_label_selector '%s' for %s: %w", resConfig.NamespaceLabelSelector, resConfig.GVR, err)
+			}
+		}
+		if resConfig.NodeName != "" {
+			if resConfig.GVR != "v1/pods" {
+				return fmt.Errorf("invalid node_name for %s, only applies to v1/pods", resConfig.GVR)
+			}
+			if os.ExpandEnv(resConfig.NodeName) == "" {
+				return fmt.Errorf("invalid node_name '%s' for %s, expands to an empty node name", resConfig.NodeName, resConfig.GVR)
+			}
+		}
+		for path := range resConfig.JSONPathSelectors {
+			if path == "" || strings.HasPrefix(path, ".") || strings.HasSuffix(path, ".") || strings.Contains(path, "..") {
+				return fmt.Errorf("invalid jsonpath_selectors path '%s' for %s, must be a dotted field path like spec.type", path, resConfig.GVR)
+			}
+		}
+		if resConfig.Scope != "" && resConfig.Scope != ClusterScope && resConfig.Scope != NamespaceScope {
+			return fmt.Errorf("invalid scope '%s' for %s, must be %s or %s", resConfig.Scope, resConfig.GVR, ClusterScope, NamespaceScope)
+		}
+		// namespace_names: [""] is the cluster-wide spelling and stays allowed
+		if resConfig.Scope == ClusterScope && (slices.ContainsFunc(resConfig.NamespaceNames, func(ns string) bool { return ns != "" }) || resConfig.NamespaceLabelSelector != "") {
+			return fmt.Errorf("invalid namespaces for %s, scope %s can't be combined with namespace_names or namespace_label_selector", resConfig.GVR, ClusterScope)
+		}
+		if resConfig.SampleRate < 0 || resConfig.SampleRate > 1 {
+			return fmt.Errorf("invalid sample_rate %v for %s, must be between 0.0 and 1.0", resConfig.SampleRate, resConfig.GVR)
+		}
+		if resConfig.MaxEventsPerSec < 0 {
+			return fmt.Errorf("invalid max_events_per_sec %v for %s, must not be negative", resConfig.MaxEventsPerSec, resConfig.GVR)
+		}
+	}
 
 	return nil
 }
 
+// checkDirWritable creates and removes a temporary file in dir, or in its nearest existing
+// ancestor when dir doesn't exist yet (it is created later, by LoadConfig or NewLogger)
+func checkDirWritable(dir string) error {
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return err
+		}
+		dir = parent
+	}
+
+	probe, err := os.CreateTemp(dir, ".faro-write-check-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
 // GetLogLevel returns the log level as an integer for klog
 func (c *Config) GetLogLevel() int {
 	switch c.LogLevel {
@@ -221,6 +666,7 @@ func (c *Config) Normalize() (map[string][]NormalizedConfig, error) {
 				GVR:            gvr,
 				NamespaceNames: []string{nsConfig.NameSelector},
 				LabelSelector:  details.LabelSelector,
+				ConfigFormat:   ConfigFormatNamespace,
 			})
 		}
 	}
@@ -232,6 +678,17 @@ func (c *Config) Normalize() (map[string][]NormalizedConfig, error) {
 			NamespaceNames: resConfig.NamespaceNames,
 			NameSelector:   resConfig.NameSelector,
 			LabelSelector:  resConfig.LabelSelector,
+			LabelSelectors: resConfig.LabelSelectors,
+			SampleRate:     resConfig.SampleRate,
+			MaxEventsPerSec: resConfig.MaxEventsPerSec,
+			Priority:       resConfig.Priority,
+			JSONPathSelectors: resConfig.JSONPathSelectors,
+			Scope:             resConfig.Scope,
+			ConfigFormat:      ConfigFormatResource,
+			ConfigID:          resConfig.ConfigID,
+			PreferredVersion:  resConfig.PreferredVersion,
+			NamespaceLabelSelector: resConfig.NamespaceLabelSelector,
+			NodeName:          os.ExpandEnv(resConfig.NodeName),
 		})
 	}
 	
@@ -250,6 +707,7 @@ func printUsage() {
 	flag.PrintDefaults()
 	fmt.Fprintf(os.Stderr, "\nExamples:\n")
 	fmt.Fprintf(os.Stderr, "  %s --config=examples/minimal-config.yaml\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "  %s --config-dir=/etc/faro/conf.d\n", os.Args[0])
 	fmt.Fprintf(os.Stderr, "  %s --output-dir=/tmp/faro --log-level=debug\n", os.Args[0])
 	fmt.Fprintf(os.Stderr, "  %s --auto-shutdown=300 --config=test.yaml\n", os.Args[0])
 	fmt.Fprintf(os.Stderr, "  %s -h\n", os.Args[0])
@@ -5,22 +5,44 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/time/rate"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	kubeversion "k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
 
+// ErrAlreadyStarted is returned by Start when the controller was already started (or stopped)
+var ErrAlreadyStarted = errors.New("controller already started")
+
+// Controller lifecycle states
+const (
+	controllerStateNew int32 = iota
+	controllerStateStarted
+	controllerStateStopped
+)
+
 // ResourceInfo holds information about a discovered API resource
 type ResourceInfo struct {
 	Group      string
@@ -28,14 +50,17 @@ type ResourceInfo struct {
 	Resource   string
 	Kind       string
 	Namespaced bool
+	Watchable  bool // Supports both list and watch, required for informers
 }
 
 // WorkItem represents a queued object key and associated metadata for processing
 type WorkItem struct {
-	Key         string             // Object key (namespace/name or name)
+	Key         string             // Object key (namespace/name or name); the informer namespace for SYNCED
 	GVRString   string             // Group/Version/Resource identifier
 	Configs     []NormalizedConfig // Configuration rules that apply to this GVR
-	EventType   string             // ADDED, UPDATED, DELETED
+	EventType   string             // ADDED, UPDATED, DELETED, SYNCED
+	ListerKey   string             // Lister holding the object (GVR@namespace, suffixed per label selector)
+	Object      *unstructured.Unstructured // Set when Key is a UID fallback key, which the lister can't resolve
 	// For DELETED events - preserve metadata that's lost when object is removed from cache
 	DeletedUID         string            // UID of deleted object
 	DeletedAnnotations map[string]string // Annotations of deleted object
@@ -43,9 +68,11 @@ type WorkItem struct {
 
 // MatchedEvent represents a filtered event that matched configuration criteria
 type MatchedEvent struct {
-	EventType string                      // ADDED, UPDATED, DELETED
+	EventType string                      // ADDED, UPDATED, DELETED, SYNCED (EmitSyncEvents, no Object)
 	Object    *unstructured.Unstructured  // Full Kubernetes object
 	GVR       string                      // Group/Version/Resource identifier
+	Resource  schema.GroupVersionResource // GVR split into group, version and resource (zero if not discovered)
+	Kind      string                      // Kind from API discovery ("" if not discovered)
 	Key       string                      // namespace/name or name
 	Config    NormalizedConfig            // Configuration that matched this event
 	Timestamp time.Time                   // When the event was processed
@@ -53,14 +80,20 @@ type MatchedEvent struct {
 
 // JSONEvent represents a structured JSON event for export
 type JSONEvent struct {
-	Timestamp   string            `json:"timestamp"`
-	EventType   string            `json:"eventType"`
-	GVR         string            `json:"gvr"`
-	Namespace   string            `json:"namespace,omitempty"`
-	Name        string            `json:"name"`
-	UID         string            `json:"uid,omitempty"`
-	Labels      map[string]string `json:"labels,omitempty"`
-	Annotations map[string]string `json:"annotations,omitempty"`
+	Timestamp   string                 `json:"timestamp"`
+	ProcessedAt string                 `json:"processedAt,omitempty"` // Processing time (JsonTimestampSource "both")
+	EventType   string                 `json:"eventType"`
+	GVR         string                 `json:"gvr"`
+	Kind        string                 `json:"kind,omitempty"` // Kind from API discovery (JsonIncludeKind)
+	Namespace   string                 `json:"namespace,omitempty"`
+	Name        string                 `json:"name"`
+	UID         string                 `json:"uid,omitempty"`
+	Labels      map[string]string      `json:"labels,omitempty"`
+	Annotations map[string]string      `json:"annotations,omitempty"`
+	Object      map[string]interface{} `json:"object,omitempty"` // Full object body (JsonIncludeObject)
+	Owners      []EventOwner           `json:"owners,omitempty"` // Owner chain, nearest first (JsonIncludeOwners)
+	ConfigID    string                 `json:"configId,omitempty"` // ConfigID of the resource config that matched the event
+	Source      string                 `json:"source,omitempty"` // LogPrefix of the controller that exported the event
 	
 	// Additional fields can be added by library users via middleware
 }
@@ -70,6 +103,35 @@ type EventHandler interface {
 	OnMatched(event MatchedEvent) error
 }
 
+// ContextEventHandler can be implemented by an EventHandler to receive a context with each
+// event. OnMatchedCtx is then called instead of OnMatched; its context is cancelled when the
+// controller stops or, with HandlerTimeoutSec, when the call takes longer than that. Handlers
+// should pass it to their network calls so a hung call cannot pin a goroutine forever.
+type ContextEventHandler interface {
+	EventHandler
+	OnMatchedCtx(ctx context.Context, event MatchedEvent) error
+}
+
+// NamedEventHandler can be implemented by an EventHandler or BatchEventHandler to label
+// its faro_event_handler_duration_seconds samples; unnamed handlers are labelled by index
+type NamedEventHandler interface {
+	Name() string
+}
+
+// ReadOnlyEventHandler can be implemented by an EventHandler that promises never to modify
+// event.Object or anything reachable from it (labels, annotations, nested maps and slices).
+// When ReadOnlyEvents returns true the handler receives the informer's cached object instead
+// of a deep copy; mutating it would corrupt the cache for Faro and every other handler.
+// All other handlers keep sharing one deep copy per event.
+type ReadOnlyEventHandler interface {
+	EventHandler
+	ReadOnlyEvents() bool
+}
+
+// TraceIDFunc returns the trace ID to link a matched event's handler duration sample with
+// (Controller.SetTraceIDFunc), or "" for no exemplar
+type TraceIDFunc func(event MatchedEvent) string
+
 // JSONMiddleware interface for processing objects before JSON logging
 type JSONMiddleware interface {
 	// ProcessBeforeJSON is called before JSON logging to allow modification of the object
@@ -81,23 +143,79 @@ type JSONMiddleware interface {
 type InformerStateTracker struct {
 	GVR           string
 	Lister        cache.GenericLister
-	UIDCache      sync.Map // map[resourceKey]string (UID)
+	UIDCache      *UIDCache // resourceKey -> UID, optionally LRU-bounded by Config.UIDCacheMaxEntries
 	SyncCompleted bool
 	mu            sync.RWMutex
+	hasSynced     cache.InformerSynced // Informer's own sync state, true even when the initial list is empty
+	lastEvent     atomic.Int64         // Unix nanoseconds of the last ADDED/UPDATED/DELETED delivered, 0 = none yet
+	seenVersions  *sync.Map            // UID -> resourceVersion, kept across informer restarts (DedupAcrossResync, nil = disabled)
+	resyncPeriod  time.Duration        // Jittered ResyncPeriodSec of the informer (0 = no resync)
+}
+
+// Informer health reported by DescribeInformers
+const (
+	InformerHealthSyncing = "syncing" // Initial list not yet complete
+	InformerHealthHealthy = "healthy" // Synced and watching
+)
+
+// InformerStatus describes one running informer (see Controller.DescribeInformers)
+type InformerStatus struct {
+	GVR           string        `json:"gvr"`
+	Namespace     string        `json:"namespace,omitempty"`     // "" for cluster-wide informers
+	LabelSelector string        `json:"labelSelector,omitempty"` // Set for the additional informers of OR-ed LabelSelectors
+	Synced        bool          `json:"synced"`
+	TrackedCount  int           `json:"trackedCount"`            // Objects currently in the informer cache
+	LastEventTime *time.Time    `json:"lastEventTime,omitempty"` // Nil (omitted) until the informer delivers an event
+	ResyncPeriod  time.Duration `json:"resyncPeriod,omitempty"`  // Jittered ResyncPeriodSec of this informer (0 = no resync)
+	Health        string        `json:"health"`                  // InformerHealth* constant
 }
 
 
 
 
 // logJSONEvent creates and logs a structured JSON event with middleware support
-func (c *Controller) logJSONEvent(eventType, gvr, namespace, name, uid string, labels map[string]string, obj *unstructured.Unstructured) {
+func (c *Controller) logJSONEvent(eventType, gvr, namespace, name, uid, configID string, labels map[string]string, obj *unstructured.Unstructured) {
+	jsonEvent, ok := c.buildJSONEvent(eventType, gvr, namespace, name, uid, labels, obj)
+	if !ok {
+		return
+	}
+	jsonEvent.ConfigID = configID
+
+	jsonData, err := json.Marshal(jsonEvent)
+	if err != nil {
+		c.logger.Warning("controller", fmt.Sprintf("Failed to marshal JSON event: %v", err))
+		return
+	}
+
+	// Log as JSON for the JSONFileHandler to pick up
+	c.logger.Debug("controller", string(jsonData))
+}
+
+// formatJSONTime formats a JSON event timestamp per JsonTimeFormat, in UTC
+func (c *Controller) formatJSONTime(t time.Time) string {
+	switch c.config.JsonTimeFormat {
+	case "":
+		return t.UTC().Format(time.RFC3339Nano)
+	case JsonTimeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case JsonTimeFormatUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return t.UTC().Format(c.config.JsonTimeFormat)
+	}
+}
+
+// buildJSONEvent applies the JSON middleware and export settings to an event. It returns
+// false when middleware drops the event.
+func (c *Controller) buildJSONEvent(eventType, gvr, namespace, name, uid string, labels map[string]string, obj *unstructured.Unstructured) (JSONEvent, bool) {
 	var objCopy *unstructured.Unstructured
 	var annotations map[string]string
 	var timestamp string
 	var finalUID string = uid
+	processedAt := c.formatJSONTime(c.now())
 
 	// Handle DELETED events - try to get UID from informer state
-	if eventType == "DELETED" {
+	if eventType == "DELETED" || eventType == EventTypeEphemeral {
 		// For DELETED events, try to get UID from informer state if not provided or unknown
 		if uid == "" || uid == "unknown" {
 			finalUID = c.getUIDFromInformerState(gvr, namespace, name)
@@ -111,7 +229,7 @@ func (c *Controller) logJSONEvent(eventType, gvr, namespace, name, uid string, l
 		
 		
 		annotations = objCopy.GetAnnotations()
-		timestamp = objCopy.GetCreationTimestamp().UTC().Format(time.RFC3339Nano)
+		timestamp = c.formatJSONTime(objCopy.GetCreationTimestamp().Time)
 	} else {
 		// For DELETED events, create a minimal object for middleware processing
 		objCopy = &unstructured.Unstructured{}
@@ -125,27 +243,33 @@ func (c *Controller) logJSONEvent(eventType, gvr, namespace, name, uid string, l
 		if annotations != nil {
 			objCopy.SetAnnotations(annotations)
 		}
-		timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+		timestamp = processedAt
+	}
+	if c.config.JsonTimestampSource == JsonTimestampProcessing {
+		timestamp = processedAt
 	}
 
-	// Apply JSON middleware to modify object before logging
+	// Apply JSON middleware to modify object before logging, GVR-scoped middleware first
 	c.middlewareMu.RLock()
+	scopedMiddleware := c.gvrJSONMiddleware[gvr]
 	middleware := c.jsonMiddleware
 	c.middlewareMu.RUnlock()
 	
 	processedObj := objCopy
 	shouldContinue := true
 	
-	for _, mw := range middleware {
-		if !shouldContinue {
-			break
+	for _, chain := range [][]JSONMiddleware{scopedMiddleware, middleware} {
+		for _, mw := range chain {
+			if !shouldContinue {
+				break
+			}
+			processedObj, shouldContinue = mw.ProcessBeforeJSON(eventType, gvr, namespace, name, finalUID, processedObj)
 		}
-		processedObj, shouldContinue = mw.ProcessBeforeJSON(eventType, gvr, namespace, name, finalUID, processedObj)
 	}
 	
 	// Skip logging if middleware says not to continue
 	if !shouldContinue {
-		return
+		return JSONEvent{}, false
 	}
 	
 	// Update annotations and labels from processed object
@@ -154,6 +278,10 @@ func (c *Controller) logJSONEvent(eventType, gvr, namespace, name, uid string, l
 		labels = processedObj.GetLabels()
 	}
 	
+	// Restrict exported metadata to the configured allow-lists
+	labels = filterStringMapKeys(labels, c.config.JsonLabelKeys)
+	annotations = filterStringMapKeys(annotations, c.config.JsonAnnotationKeys)
+	
 	jsonEvent := JSONEvent{
 		Timestamp:   timestamp,
 		EventType:   eventType,
@@ -163,51 +291,179 @@ func (c *Controller) logJSONEvent(eventType, gvr, namespace, name, uid string, l
 		UID:         finalUID,
 		Labels:      labels,
 		Annotations: annotations,
+		Source:      c.logger.prefix,
+	}
+	if c.config.JsonTimestampSource == JsonTimestampBoth {
+		jsonEvent.ProcessedAt = processedAt
 	}
 
-	// Special field extraction removed - library users should implement via middleware if needed
+	// Optionally record the Kind known from API discovery
+	if c.config.JsonIncludeKind {
+		c.discoveredResourcesMu.RLock()
+		if info, found := c.discoveredResources[gvr]; found {
+			jsonEvent.Kind = info.Kind
+		}
+		c.discoveredResourcesMu.RUnlock()
+	}
 
-	jsonData, err := json.Marshal(jsonEvent)
-	if err != nil {
-		c.logger.Warning("controller", fmt.Sprintf("Failed to marshal JSON event: %v", err))
-		return
+	// Optionally record the owner chain from the informer caches
+	if c.ownerResolver != nil && obj != nil {
+		jsonEvent.Owners = c.ownerResolver.Resolve(obj)
 	}
 
-	// Log as JSON for the JSONFileHandler to pick up
-	c.logger.Debug("controller", string(jsonData))
+	// Optionally record the whole object; DELETED events without a final state have no body
+	if c.config.JsonIncludeObject && obj != nil && processedObj != nil {
+		jsonEvent.Object = processedObj.Object
+		if c.config.JsonStripManagedFields {
+			unstructured.RemoveNestedField(jsonEvent.Object, "metadata", "managedFields")
+		}
+	}
+
+	// Special field extraction removed - library users should implement via middleware if needed
+
+	return jsonEvent, true
 }
 
 
+// trackersForNamespace returns the state trackers of the informers that cache objects of
+// gvrString in namespace: the namespace's own informers and all-namespace ones (trackers are
+// keyed by lister key, "gvr@namespace" optionally followed by "|selector")
+func (c *Controller) trackersForNamespace(gvrString, namespace string) []*InformerStateTracker {
+	var trackers []*InformerStateTracker
+	c.informerTrackers.Range(func(key, value interface{}) bool {
+		for _, ns := range []string{namespace, ""} {
+			prefix := gvrString + "@" + ns
+			if key.(string) == prefix || strings.HasPrefix(key.(string), prefix+"|") {
+				trackers = append(trackers, value.(*InformerStateTracker))
+				break
+			}
+		}
+		return true
+	})
+	return trackers
+}
+
 // getUIDFromInformerState retrieves UID from informer state tracker
 func (c *Controller) getUIDFromInformerState(gvrString, namespace, name string) string {
-	trackerInterface, exists := c.informerTrackers.Load(gvrString)
-	if !exists {
+	trackers := c.trackersForNamespace(gvrString, namespace)
+	if len(trackers) == 0 {
 		c.metrics.OnUIDResolution(gvrString, "cache_miss")
 		return "unknown" // No tracker for this GVR
 	}
 	
-	tracker := trackerInterface.(*InformerStateTracker)
 	key := c.makeResourceKey(gvrString, namespace, name)
-	
-	if cachedUID, exists := tracker.UIDCache.Load(key); exists {
+	for _, tracker := range trackers {
+		if cachedUID, exists := tracker.UIDCache.Load(key); exists {
+			c.metrics.OnUIDResolution(gvrString, "success")
+			return cachedUID
+		}
+	}
+	if storedUID, exists := c.loadStoredUID(key); exists {
 		c.metrics.OnUIDResolution(gvrString, "success")
-		return cachedUID.(string)
+		return storedUID
 	}
 	
 	c.metrics.OnUIDResolution(gvrString, "unknown")
 	return "unknown" // Not found in informer state
 }
 
+// fallbackDeleteUID resolves the UID of a DELETED event missing from every cache: the deleted
+// object's own UID when the informer still had it (obj may be nil), else according to
+// DeleteUIDPolicy. It reports false under the default "require" policy, when the event must
+// be dropped.
+func (c *Controller) fallbackDeleteUID(gvrString, namespace, name string, obj *unstructured.Unstructured) (string, bool) {
+	if obj != nil && obj.GetUID() != "" {
+		return string(obj.GetUID()), true
+	}
+
+	switch c.config.DeleteUIDPolicy {
+	case DeleteUIDPolicyUnknown:
+		c.logger.Warning("controller", fmt.Sprintf("No cached UID for DELETED %s %s/%s, emitting it with an unknown UID", gvrString, namespace, name))
+		return "unknown", true
+	case DeleteUIDPolicyBestEffort:
+		// The object may still be finalizing. Only an object marked for deletion counts: any other
+		// object found under the name may already be a re-created one with a new UID.
+		c.discoveredResourcesMu.RLock()
+		resourceInfo, found := c.discoveredResources[gvrString]
+		c.discoveredResourcesMu.RUnlock()
+		if found {
+			gvr := schema.GroupVersionResource{Group: resourceInfo.Group, Version: resourceInfo.Version, Resource: resourceInfo.Resource}
+			current, err := c.client.DynamicClient().Resource(gvr).Namespace(namespace).Get(c.ctx, name, metav1.GetOptions{})
+			if err == nil && current.GetUID() != "" && current.GetDeletionTimestamp() != nil {
+				return string(current.GetUID()), true
+			}
+		}
+		c.logger.Warning("controller", fmt.Sprintf("Could not resolve the UID of DELETED %s %s/%s, emitting it with an unknown UID", gvrString, namespace, name))
+		return "unknown", true
+	default:
+		return "", false
+	}
+}
+
 // cleanupUIDFromInformerState removes UID from informer state tracker after processing
 func (c *Controller) cleanupUIDFromInformerState(gvrString, namespace, name string) {
-	trackerInterface, exists := c.informerTrackers.Load(gvrString)
-	if !exists {
-		return // No tracker for this GVR
+	key := c.makeResourceKey(gvrString, namespace, name)
+	c.deleteStoredUID(key)
+
+	for _, tracker := range c.trackersForNamespace(gvrString, namespace) {
+		tracker.UIDCache.Delete(key)
 	}
-	
-	tracker := trackerInterface.(*InformerStateTracker)
+}
+
+// IsTracked reports whether an object is currently tracked by Faro's informers and returns its
+// UID, without fetching the object. It answers from the UID caches, so it is cheaper than
+// GetObject; an object stops being tracked once its DELETED event has been processed.
+func (c *Controller) IsTracked(gvrString, namespace, name string) (uid string, ok bool) {
 	key := c.makeResourceKey(gvrString, namespace, name)
-	tracker.UIDCache.Delete(key)
+	for _, tracker := range c.trackersForNamespace(gvrString, namespace) {
+		if uid, ok := tracker.UIDCache.Load(key); ok {
+			return uid, true
+		}
+	}
+	return "", false
+}
+
+// getUIDStore returns the store set with SetUIDStore, or nil
+func (c *Controller) getUIDStore() UIDStore {
+	c.uidStoreMu.RLock()
+	defer c.uidStoreMu.RUnlock()
+	return c.uidStore
+}
+
+// saveStoredUID writes a UID through to the UID store, if one is set
+func (c *Controller) saveStoredUID(key, uid string) {
+	store := c.getUIDStore()
+	if store == nil {
+		return
+	}
+	if err := store.Put(key, uid); err != nil {
+		c.logger.Warning("controller", fmt.Sprintf("Failed to store UID for %s: %v", key, err))
+	}
+}
+
+// loadStoredUID looks up a UID missing from the in-memory caches in the UID store, if one is set
+func (c *Controller) loadStoredUID(key string) (string, bool) {
+	store := c.getUIDStore()
+	if store == nil {
+		return "", false
+	}
+	uid, found, err := store.Get(key)
+	if err != nil {
+		c.logger.Warning("controller", fmt.Sprintf("Failed to load stored UID for %s: %v", key, err))
+		return "", false
+	}
+	return uid, found
+}
+
+// deleteStoredUID removes a deleted object's UID from the UID store, if one is set
+func (c *Controller) deleteStoredUID(key string) {
+	store := c.getUIDStore()
+	if store == nil {
+		return
+	}
+	if err := store.Delete(key); err != nil {
+		c.logger.Warning("controller", fmt.Sprintf("Failed to delete stored UID for %s: %v", key, err))
+	}
 }
 
 
@@ -232,6 +488,21 @@ func (c *Controller) copyStringMap(original map[string]string) map[string]string
 	return copy
 }
 
+// filterStringMapKeys returns only the entries of original whose keys are in allowed.
+// An empty allow-list keeps every entry.
+func filterStringMapKeys(original map[string]string, allowed []string) map[string]string {
+	if len(allowed) == 0 || original == nil {
+		return original
+	}
+	filtered := make(map[string]string, len(allowed))
+	for _, key := range allowed {
+		if value, exists := original[key]; exists {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
 // populateInitialUIDCache populates the UID cache with existing resources from informer
 func (c *Controller) populateInitialUIDCache(tracker *InformerStateTracker, config InformerConfig) int64 {
 	var objects []runtime.Object
@@ -294,14 +565,38 @@ func (c *Controller) setupSyncCallback(informer cache.SharedIndexInformer, track
 }
 
 // createStateTrackingEventHandlers creates event handlers that maintain UID state
-func (c *Controller) createStateTrackingEventHandlers(tracker *InformerStateTracker, config InformerConfig) cache.ResourceEventHandlerFuncs {
-	return cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
+func (c *Controller) createStateTrackingEventHandlers(tracker *InformerStateTracker, config InformerConfig) cache.ResourceEventHandlerDetailedFuncs {
+	return cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
 			if unstructured, ok := obj.(*unstructured.Unstructured); ok {
+				tracker.lastEvent.Store(c.now().UnixNano())
+				
 				// Update UID cache
 				key := c.makeResourceKey(config.GVRString, unstructured.GetNamespace(), unstructured.GetName())
 				uid := string(unstructured.GetUID())
 				tracker.UIDCache.Store(key, uid)
+				c.saveStoredUID(key, uid)
+				
+				// A restarted informer re-lists objects its predecessor already reported
+				if tracker.seenVersions != nil {
+					previousVersion, seen := tracker.seenVersions.Swap(uid, unstructured.GetResourceVersion())
+					if seen && previousVersion == unstructured.GetResourceVersion() {
+						c.logger.Debug("controller", fmt.Sprintf("Dropped duplicate ADDED event for %s %s after informer restart", config.GVRString, key))
+						return
+					}
+					if seen {
+						c.metrics.OnEventProcessed(config.GVRString, "UPDATED", unstructured.GetNamespace())
+						config.HandlerFunc("UPDATED", unstructured)
+						return
+					}
+				}
+				
+				// Pre-existing objects only seed the UID cache - later UPDATED/DELETED events still resolve them
+				if isInInitialList && c.config.SuppressInitialAdds {
+					c.metrics.OnResourceTracked(config.GVRString, unstructured.GetNamespace(), 1)
+					c.logger.Debug("controller", fmt.Sprintf("Suppressed initial ADDED event for %s %s", config.GVRString, key))
+					return
+				}
 				
 				// Update metrics
 				c.metrics.OnEventProcessed(config.GVRString, "ADDED", unstructured.GetNamespace())
@@ -315,10 +610,16 @@ func (c *Controller) createStateTrackingEventHandlers(tracker *InformerStateTrac
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			if unstructured, ok := newObj.(*unstructured.Unstructured); ok {
+				tracker.lastEvent.Store(c.now().UnixNano())
+				
 				// Update UID cache (UID shouldn't change, but keep it current)
 				key := c.makeResourceKey(config.GVRString, unstructured.GetNamespace(), unstructured.GetName())
 				uid := string(unstructured.GetUID())
 				tracker.UIDCache.Store(key, uid)
+				c.saveStoredUID(key, uid)
+				if tracker.seenVersions != nil {
+					tracker.seenVersions.Store(uid, unstructured.GetResourceVersion())
+				}
 				
 				// Update metrics
 				c.metrics.OnEventProcessed(config.GVRString, "UPDATED", unstructured.GetNamespace())
@@ -335,11 +636,17 @@ func (c *Controller) createStateTrackingEventHandlers(tracker *InformerStateTrac
 			
 			// Handle tombstone
 			if tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown); isTombstone {
-				unstructuredObj, ok = tombstone.Obj.(*unstructured.Unstructured)
-				if !ok {
-					c.logger.Error("controller", fmt.Sprintf("Tombstone contained unexpected object type for %s", config.GVRString))
+				var result string
+				unstructuredObj, result = DeletedObjectFromTombstone(tombstone)
+				c.metrics.OnTombstoneEvent(config.GVRString, result)
+				if unstructuredObj == nil {
+					c.logger.Error("controller", fmt.Sprintf("Dropping tombstone with unexpected object type %T and key %q for %s", tombstone.Obj, tombstone.Key, config.GVRString))
 					return
 				}
+				if result == TombstoneResultReconstructed {
+					c.logger.Warning("controller", fmt.Sprintf("Reconstructed deleted object %q from tombstone for %s", tombstone.Key, config.GVRString))
+				}
+				ok = true
 			} else {
 				unstructuredObj, ok = obj.(*unstructured.Unstructured)
 				if !ok {
@@ -349,15 +656,25 @@ func (c *Controller) createStateTrackingEventHandlers(tracker *InformerStateTrac
 			}
 			
 			if ok {
+				tracker.lastEvent.Store(c.now().UnixNano())
 				key := c.makeResourceKey(config.GVRString, unstructuredObj.GetNamespace(), unstructuredObj.GetName())
 				
-				// Get UID from cache before deletion (for logging) - no fallbacks
-				cachedUID, exists := tracker.UIDCache.Load(key)
+				// Get UID from cache before deletion (for logging), falling back to the UID store
+				uid, exists := tracker.UIDCache.Load(key)
+				if !exists {
+					uid, exists = c.loadStoredUID(key)
+				}
+				if !exists {
+					uid, exists = c.fallbackDeleteUID(config.GVRString, unstructuredObj.GetNamespace(), unstructuredObj.GetName(), unstructuredObj)
+				}
 				if !exists {
 					c.logger.Error("controller", "No cached UID for DELETED event: "+key)
 					return
 				}
-				uid := cachedUID.(string)
+				
+				if tracker.seenVersions != nil {
+					tracker.seenVersions.Delete(uid)
+				}
 				
 				// Update metrics
 				c.metrics.OnEventProcessed(config.GVRString, "DELETED", unstructuredObj.GetNamespace())
@@ -388,56 +705,140 @@ type InformerConfig struct {
 	Context     context.Context
 	HandlerFunc func(eventType string, obj *unstructured.Unstructured)
 	Name        string // For logging purposes
+	ListerKey     string // Lister/tracker key (default GVR@namespace)
+	LabelSelector string // Overrides the configs' label selector (one informer per LabelSelectors entry)
 }
 
 // Controller implements the sophisticated multi-layered informer architecture
 type Controller struct {
-	client *KubernetesClient
+	client ClusterClient
 	logger *Logger
 	config *Config
 
-	// Context management
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	resourcesMu sync.RWMutex // Protects config.Resources, appended by AddResources while running
 
-	// Work queue for processing events asynchronously
-	workQueue workqueue.RateLimitingInterface
+	// Context management. Informers run on informerCtx, a child of ctx, so Stop can end them
+	// first and still deliver the events they queued under the live ctx
+	ctx             context.Context
+	cancel          context.CancelFunc
+	informerCtx     context.Context
+	cancelInformers context.CancelFunc
+	wg     sync.WaitGroup
+	state  atomic.Int32 // controllerStateNew -> controllerStateStarted -> controllerStateStopped
+	paused atomic.Bool  // Pause/Resume: informers keep running but no work items are queued
+
+	// Work queue for processing events asynchronously. The queue holds object keys (GVR|key),
+	// so the workqueue never hands one object to two workers; the events themselves wait in
+	// pendingItems in arrival order.
+	workQueue      workqueue.RateLimitingInterface
+	pendingItems   map[string][]*WorkItem
+	inFlightItems  int // Work items taken by workers and not yet processed, guarded by pendingItemsMu
+	pendingItemsMu sync.Mutex
 	workers   int // Number of worker goroutines
+	debouncer *eventDebouncer // Collapses rapid events per object before queueing (nil = disabled)
+
+	// Last dispatched events for replay (EventRingSize, nil = disabled)
+	eventRing *eventRing
 
 	// API discovery results
 	discoveredResources   map[string]*ResourceInfo // map[GVR] -> ResourceInfo
-	discoveredResourcesMu sync.RWMutex             // Protects discoveredResources map
+	discoveredKinds       map[schema.GroupVersionKind]string // map[GVK] -> GVR, subresources excluded
+	preferredVersions     map[string]string        // map[group] -> preferred version reported by discovery
+	unavailableGroupVersions map[schema.GroupVersion]error // Group versions whose discovery failed, e.g. a down aggregated API
+	discoveredResourcesMu sync.RWMutex             // Protects discoveredResources, discoveredKinds, preferredVersions and unavailableGroupVersions
+	discoveryCache        *DiscoveryCache          // Discovery results shared with other controllers (nil = not shared)
 
 	// Informer lifecycle management - using GVR string as consistent key
 	cancellers      sync.Map // map[string]context.CancelFunc for informer shutdown
-	activeInformers sync.Map // map[string]bool for tracking active informers by GVR
+	activeInformers sync.Map // map[string]string informer key -> lister/tracker key for active informers
+	informerExits   sync.Map // map[string]chan struct{} informer key -> closed once the informer has exited
 	listers         sync.Map // map[string]cache.GenericLister for object retrieval
+	namespaceWatchers sync.Map // map[string]*namespaceWatcher "gvr|selector" of running NamespaceLabelSelector watchers
 
 
 	// Event handlers for library usage
-	eventHandlers []EventHandler
+	eventHandlers    []registeredHandler
+	gvrEventHandlers map[string][]registeredHandler // Handlers only receiving events of one GVR
+	batchers      []*eventBatcher // One per BatchEventHandler
+	handlerSlots  chan struct{}   // Semaphore of MaxHandlerConcurrency handler goroutines (nil = unlimited)
+	workersDrained chan struct{}  // Closed once Stop stopped waiting for the workers, ends HandlerConcurrencyPolicyBlock waits
+	traceIDFunc   TraceIDFunc // Trace IDs for handler duration exemplars (SetTraceIDFunc)
 	handlersMu    sync.RWMutex
 
 	// JSON middleware for processing objects before JSON logging
 	jsonMiddleware []JSONMiddleware
+	gvrJSONMiddleware map[string][]JSONMiddleware // Only run for events of the GVR key (AddJSONMiddlewareForGVR)
 	middlewareMu   sync.RWMutex
 
+	// Object transforms applied before caching (SetTransform / SetTransformForGVR)
+	transform     TransformFunc
+	gvrTransforms map[string]TransformFunc
+	transformMu   sync.RWMutex
+
 	// Informer state tracking for UID preservation
 	informerTrackers sync.Map // map[string]*InformerStateTracker for UID tracking per GVR
 	
 	// Metrics collection
 	metrics *MetricsCollector
 
+	// Compiled JSONPathSelectors paths (dotted path -> field path)
+	jsonPaths sync.Map
+
+	// Token buckets of GVRs with MaxEventsPerSec (GVR -> *rate.Limiter)
+	eventLimiters sync.Map
+
+	// Source of the current time (SetClock)
+	clock   Clock
+	clockMu sync.RWMutex
+
+	// Events already queued by another informer of an OR-ed LabelSelectors set (uid|type|resourceVersion)
+	selectorDedup   *UIDCache
+	selectorDedupMu sync.Mutex
+
+	// Owner chain enrichment for JSON events (nil = disabled)
+	ownerResolver *OwnerResolver
+
+	// External UID store (SetUIDStore, nil = in-memory caches only)
+	uidStore   UIDStore
+	uidStoreMu sync.RWMutex
+
 	// Readiness callback
 	onReady   func()
 	readyMu   sync.Mutex
 	isReady   bool
+
+	// Shutdown callbacks, run in registration order at the start of Stop
+	onShutdown   []func()
+	shutdownMu   sync.Mutex
+
+	// Runtime CRD discovery callback (WatchCRDs)
+	onCRDDiscovered func(crdName, gvrString string)
+	crdCallbackMu   sync.RWMutex
+	// Informer teardowns of deleted CRDs waiting out CRDReAddDebounceSec, keyed by CRD name
+	pendingCRDTeardowns   map[string]*pendingCRDTeardown
+	pendingCRDTeardownsMu sync.Mutex
+
+	// Informer circuit breaker callback (MaxInformerRestarts)
+	onInformerFailed func(gvrString, namespace string, err error)
+	informerFailedMu sync.RWMutex
 }
 
-// NewController creates an informer-based controller
-func NewController(client *KubernetesClient, logger *Logger, config *Config) *Controller {
+// NewControllerWithDiscovery creates a controller that takes its API discovery results from
+// cache, so controllers sharing one cache discover the cluster once between them
+func NewControllerWithDiscovery(client ClusterClient, logger *Logger, config *Config, cache *DiscoveryCache) *Controller {
+	controller := NewController(client, logger, config)
+	controller.discoveryCache = cache
+	return controller
+}
+
+// NewController creates an informer-based controller. client is usually a *KubernetesClient;
+// tests can pass any ClusterClient, e.g. one wrapping the client-go fake clients.
+func NewController(client ClusterClient, logger *Logger, config *Config) *Controller {
 	ctx, cancel := context.WithCancel(context.Background())
+	informerCtx, cancelInformers := context.WithCancel(ctx)
+	if config.LogPrefix != "" {
+		logger = logger.WithPrefix(config.LogPrefix)
+	}
 
 	controller := &Controller{
 		client:              client,
@@ -445,26 +846,315 @@ func NewController(client *KubernetesClient, logger *Logger, config *Config) *Co
 		config:              config,
 		ctx:                 ctx,
 		cancel:              cancel,
+		informerCtx:         informerCtx,
+		cancelInformers:     cancelInformers,
 		workQueue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "faro-controller"),
+		pendingItems:        make(map[string][]*WorkItem),
 		workers:             3, // Start with 3 worker goroutines
 		discoveredResources: make(map[string]*ResourceInfo),
-		eventHandlers:       make([]EventHandler, 0),
+		discoveredKinds:     make(map[schema.GroupVersionKind]string),
+		clock:               realClock{},
+		eventHandlers:       make([]registeredHandler, 0),
 		jsonMiddleware:      make([]JSONMiddleware, 0),
-		metrics:             NewMetricsCollector(config.Metrics, *logger),
+		metrics:             NewMetricsCollector(config.Metrics, logger),
+		selectorDedup:       NewUIDCache(selectorDedupEntries, nil),
+	}
+	
+	controller.metrics.setConfigSource(controller.configSnapshot)
+	controller.metrics.SetProbeChecks(controller.Healthy, controller.Ready)
+	
+	if config.JsonIncludeOwners {
+		controller.ownerResolver = NewOwnerResolver(controller)
+	}
+	
+	if config.MaxHandlerConcurrency > 0 {
+		controller.handlerSlots = make(chan struct{}, config.MaxHandlerConcurrency)
+		controller.workersDrained = make(chan struct{})
+	}
+	
+	if config.DedupWindowMs > 0 {
+		controller.debouncer = newEventDebouncer(time.Duration(config.DedupWindowMs)*time.Millisecond, config.DedupMode, func(item *WorkItem) {
+			controller.enqueueWorkItem(item)
+		})
+		controller.debouncer.coalesceShortLived = config.CoalesceShortLived
+		controller.debouncer.dropShortLived = config.ShortLivedMode == ShortLivedModeDrop
+		controller.debouncer.discard = func(item *WorkItem) {
+			// The DELETED event is never processed, so release the UID its ADDED cached
+			namespace, name, _ := cache.SplitMetaNamespaceKey(item.Key)
+			if item.Object != nil {
+				name = item.Object.GetName()
+			}
+			controller.cleanupUIDFromInformerState(item.GVRString, namespace, name)
+		}
+	}
+	
+	if config.EventRingSize > 0 {
+		controller.eventRing = newEventRing(config.EventRingSize)
 	}
 	
 	logger.Debug("controller", "Created new controller instance")
 	return controller
 }
 
+// registeredHandler is an EventHandler with the name its metrics are labelled with and,
+// when HandlerQueueSize is set, its delivery queue
+type registeredHandler struct {
+	handler EventHandler
+	name    string
+	queue   *deliveryQueue
+}
+
+// registerHandler names handler and creates its delivery queue when configured
+func (c *Controller) registerHandler(handler EventHandler, fallbackName string) registeredHandler {
+	registered := registeredHandler{handler: handler, name: handlerName(handler, fallbackName)}
+	if c.config.HandlerQueueSize > 0 {
+		name := registered.name
+		registered.queue = newDeliveryQueue(func(event MatchedEvent) error {
+			return c.callHandler(handler, name, event)
+		}, c.config.HandlerQueueSize, c.config.HandlerQueuePolicy, func(event MatchedEvent, duration time.Duration, err error) {
+			c.metrics.OnHandlerCompletedWithTrace(name, duration, c.eventTraceID(event))
+			if err != nil {
+				c.logger.Warning("controller", fmt.Sprintf("Event handler %s failed: %v", name, err))
+			}
+		}, func() {
+			c.metrics.OnEventDeliveryDropped(name)
+		})
+	}
+	return registered
+}
+
 // AddEventHandler registers an event handler for matched events
 func (c *Controller) AddEventHandler(handler EventHandler) {
 	c.handlersMu.Lock()
 	defer c.handlersMu.Unlock()
-	c.eventHandlers = append(c.eventHandlers, handler)
+	c.eventHandlers = append(c.eventHandlers, c.registerHandler(handler, fmt.Sprintf("handler-%d", len(c.eventHandlers))))
 	c.logger.Debug("controller", fmt.Sprintf("Added event handler (total: %d)", len(c.eventHandlers)))
 }
 
+// AddEventHandlerForGVR registers an event handler that only receives matched events of gvr
+// (e.g. "apps/v1/deployments"), so it doesn't need to filter on event.GVR itself
+func (c *Controller) AddEventHandlerForGVR(gvr string, handler EventHandler) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	if c.gvrEventHandlers == nil {
+		c.gvrEventHandlers = make(map[string][]registeredHandler)
+	}
+	fallbackName := fmt.Sprintf("%s-handler-%d", gvr, len(c.gvrEventHandlers[gvr]))
+	c.gvrEventHandlers[gvr] = append(c.gvrEventHandlers[gvr], c.registerHandler(handler, fallbackName))
+	c.logger.Debug("controller", fmt.Sprintf("Added event handler for %s (total: %d)", gvr, len(c.gvrEventHandlers[gvr])))
+}
+
+// AddBatchEventHandler registers a handler that receives matched events in batches of
+// BatchSize, flushed early after BatchIntervalMs. DELETED events flush the batch immediately.
+func (c *Controller) AddBatchEventHandler(handler BatchEventHandler) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+
+	name := handlerName(handler, fmt.Sprintf("batch-handler-%d", len(c.batchers)))
+	batcher := newEventBatcher(handler, c.config.BatchSize, time.Duration(c.config.BatchIntervalMs)*time.Millisecond, func(duration time.Duration, err error) {
+		c.metrics.OnHandlerCompleted(name, duration)
+		if err != nil {
+			c.logger.Warning("controller", fmt.Sprintf("Batch event handler %s failed: %v", name, err))
+		}
+	})
+	c.batchers = append(c.batchers, batcher)
+	c.logger.Debug("controller", fmt.Sprintf("Added batch event handler (total: %d)", len(c.batchers)))
+}
+
+// SetTraceIDFunc sets a TraceIDFunc whose trace IDs are attached to handler duration samples
+// as exemplars, e.g. the ID of the OTel span a handler starts for the event. Exemplars are
+// served in the OpenMetrics format of the metrics endpoint. nil disables them.
+func (c *Controller) SetTraceIDFunc(traceID TraceIDFunc) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.traceIDFunc = traceID
+}
+
+// eventTraceID returns the SetTraceIDFunc trace ID of event, or "" without a TraceIDFunc
+func (c *Controller) eventTraceID(event MatchedEvent) string {
+	c.handlersMu.RLock()
+	traceID := c.traceIDFunc
+	c.handlersMu.RUnlock()
+	if traceID == nil {
+		return ""
+	}
+	return traceID(event)
+}
+
+// callHandler delivers event to handler, through OnMatchedCtx with a context bounded by
+// HandlerTimeoutSec for a ContextEventHandler. The context derives from c.ctx, which Stop only
+// cancels once the queued events were delivered. A call that runs into the timeout is counted
+// and reported as an error, whatever the handler returned.
+func (c *Controller) callHandler(handler EventHandler, name string, event MatchedEvent) error {
+	ctxHandler, ok := handler.(ContextEventHandler)
+	if !ok {
+		return handler.OnMatched(event)
+	}
+
+	ctx, cancel := c.ctx, context.CancelFunc(func() {})
+	if c.config.HandlerTimeoutSec > 0 {
+		ctx, cancel = context.WithTimeout(c.ctx, time.Duration(c.config.HandlerTimeoutSec)*time.Second)
+	}
+	defer cancel()
+
+	err := ctxHandler.OnMatchedCtx(ctx, event)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		c.metrics.OnHandlerTimeout(name)
+		return fmt.Errorf("handler %s timed out after %ds: %w", name, c.config.HandlerTimeoutSec, ctx.Err())
+	}
+	return err
+}
+
+// handlerName returns the handler's Name() when it implements NamedEventHandler, or fallback
+func handlerName(handler interface{}, fallback string) string {
+	if named, ok := handler.(NamedEventHandler); ok && named.Name() != "" {
+		return named.Name()
+	}
+	return fallback
+}
+
+// dispatchMatchedEvent delivers a matched event to all event handlers and the handlers
+// registered for its GVR without blocking Faro, and queues it for every batch event handler.
+// event.Object may be the informer's cached object: read-only handlers get it as is,
+// everyone else one shared deep copy.
+func (c *Controller) dispatchMatchedEvent(event MatchedEvent) {
+	c.handlersMu.RLock()
+	handlers := c.eventHandlers
+	scopedHandlers := c.gvrEventHandlers[event.GVR]
+	batchers := c.batchers
+	c.handlersMu.RUnlock()
+
+	// Copy lazily so events only reaching read-only handlers are never copied
+	var copied *MatchedEvent
+	copiedEvent := func() MatchedEvent {
+		if copied == nil {
+			eventCopy := event
+			if event.Object != nil {
+				eventCopy.Object = event.Object.DeepCopy() // Deep copy to prevent concurrent access by event handlers
+			}
+			copied = &eventCopy
+		}
+		return *copied
+	}
+
+	for _, group := range [][]registeredHandler{handlers, scopedHandlers} {
+		for _, registered := range group {
+			delivered := event
+			if readOnly, ok := registered.handler.(ReadOnlyEventHandler); !ok || !readOnly.ReadOnlyEvents() {
+				delivered = copiedEvent()
+			}
+
+			// Hand the event to the handler's bounded queue, or call it in a goroutine of its own
+			if registered.queue != nil {
+				registered.queue.Add(delivered)
+				continue
+			}
+			if !c.acquireHandlerSlot(registered.name) {
+				continue
+			}
+			c.wg.Add(1) // Stop waits for the handlers of drained events before cancelling ctx
+			go func(h EventHandler, name string, event MatchedEvent) {
+				defer c.wg.Done()
+				defer c.releaseHandlerSlot()
+				started := time.Now()
+				err := c.callHandler(h, name, event)
+				c.metrics.OnHandlerCompletedWithTrace(name, time.Since(started), c.eventTraceID(event))
+				if err != nil {
+					c.logger.Warning("controller", fmt.Sprintf("Event handler failed for %s: %v", event.EventType, err))
+				}
+			}(registered.handler, registered.name, delivered)
+		}
+	}
+
+	// Batches outlive this call, so they always hold the copy
+	for _, batcher := range batchers {
+		batcher.Add(copiedEvent())
+	}
+
+	// The ring keeps a copy of its own, handlers may still modify theirs
+	if c.eventRing != nil {
+		buffered := event
+		if event.Object != nil {
+			buffered.Object = event.Object.DeepCopy()
+		}
+		c.eventRing.add(buffered, c.now())
+	}
+}
+
+// RecentEvents returns up to the n most recent matched events, oldest first, from the ring of
+// the last EventRingSize events (n <= 0 = all of them). A handler registered late can replay
+// them to catch up. Returns nil when EventRingSize is not set.
+func (c *Controller) RecentEvents(n int) []MatchedEvent {
+	if c.eventRing == nil {
+		return nil
+	}
+	entries := c.eventRing.entries()
+	if n > 0 && n < len(entries) {
+		entries = entries[len(entries)-n:]
+	}
+	return replayEvents(entries)
+}
+
+// EventsSince returns the buffered matched events dispatched after since, oldest first (see
+// RecentEvents). Events that already left the ring are not returned.
+func (c *Controller) EventsSince(since time.Time) []MatchedEvent {
+	if c.eventRing == nil {
+		return nil
+	}
+	var recent []ringEntry
+	for _, entry := range c.eventRing.entries() {
+		if entry.dispatched.After(since) {
+			recent = append(recent, entry)
+		}
+	}
+	return replayEvents(recent)
+}
+
+// replayEvents copies buffered events for a caller, so modifying them cannot change the ring
+func replayEvents(entries []ringEntry) []MatchedEvent {
+	events := make([]MatchedEvent, 0, len(entries))
+	for _, entry := range entries {
+		event := entry.event
+		if event.Object != nil {
+			event.Object = event.Object.DeepCopy()
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// acquireHandlerSlot takes one of the MaxHandlerConcurrency handler slots, waiting for a free
+// one or counting a drop for the handler per HandlerConcurrencyPolicy. It reports whether the
+// handler may be called.
+func (c *Controller) acquireHandlerSlot(name string) bool {
+	if c.handlerSlots == nil {
+		return true
+	}
+	select {
+	case c.handlerSlots <- struct{}{}:
+		return true
+	default:
+	}
+
+	// Blocked workers only give up once Stop stopped waiting for them
+	if c.config.HandlerConcurrencyPolicy == HandlerConcurrencyPolicyBlock {
+		select {
+		case c.handlerSlots <- struct{}{}:
+			return true
+		case <-c.workersDrained:
+		}
+	}
+	c.metrics.OnHandlerConcurrencyDropped(name)
+	return false
+}
+
+// releaseHandlerSlot frees a slot taken by acquireHandlerSlot
+func (c *Controller) releaseHandlerSlot() {
+	if c.handlerSlots != nil {
+		<-c.handlerSlots
+	}
+}
+
 // AddJSONMiddleware registers a JSON middleware for processing objects before JSON logging
 func (c *Controller) AddJSONMiddleware(middleware JSONMiddleware) {
 	c.middlewareMu.Lock()
@@ -473,6 +1163,70 @@ func (c *Controller) AddJSONMiddleware(middleware JSONMiddleware) {
 	c.logger.Debug("controller", fmt.Sprintf("Added JSON middleware (total: %d)", len(c.jsonMiddleware)))
 }
 
+// AddJSONMiddlewareForGVR registers a JSON middleware that only runs for events of gvr
+// (e.g. "apps/v1/deployments"). GVR-scoped middleware runs before the generic middleware.
+func (c *Controller) AddJSONMiddlewareForGVR(gvr string, middleware JSONMiddleware) {
+	c.middlewareMu.Lock()
+	defer c.middlewareMu.Unlock()
+	if c.gvrJSONMiddleware == nil {
+		c.gvrJSONMiddleware = make(map[string][]JSONMiddleware)
+	}
+	c.gvrJSONMiddleware[gvr] = append(c.gvrJSONMiddleware[gvr], middleware)
+	c.logger.Debug("controller", fmt.Sprintf("Added JSON middleware for %s (total: %d)", gvr, len(c.gvrJSONMiddleware[gvr])))
+}
+
+// SetTransform sets a TransformFunc applied to the objects of every GVR before they are cached,
+// e.g. DropFields("metadata.managedFields"). Call it before Start; informers keep the
+// transform they were created with.
+func (c *Controller) SetTransform(transform TransformFunc) {
+	c.transformMu.Lock()
+	defer c.transformMu.Unlock()
+	c.transform = transform
+}
+
+// SetTransformForGVR sets a TransformFunc for the objects of gvr (e.g. "v1/configmaps"),
+// replacing the SetTransform one for that GVR
+func (c *Controller) SetTransformForGVR(gvr string, transform TransformFunc) {
+	c.transformMu.Lock()
+	defer c.transformMu.Unlock()
+	if c.gvrTransforms == nil {
+		c.gvrTransforms = make(map[string]TransformFunc)
+	}
+	c.gvrTransforms[gvr] = transform
+}
+
+// objectTransform returns the TransformFunc for gvr, NoTransform if none is set
+func (c *Controller) objectTransform(gvr string) TransformFunc {
+	c.transformMu.RLock()
+	defer c.transformMu.RUnlock()
+	if transform, ok := c.gvrTransforms[gvr]; ok && transform != nil {
+		return transform
+	}
+	if c.transform != nil {
+		return c.transform
+	}
+	return NoTransform
+}
+
+
+// SetClock replaces the clock the controller reads the current time from (processedAt, SYNCED
+// and DELETED timestamps, last event times, informer failure windows). Call it before Start;
+// durations such as handler latencies are always measured in real time.
+func (c *Controller) SetClock(clock Clock) {
+	if clock == nil {
+		clock = realClock{}
+	}
+	c.clockMu.Lock()
+	defer c.clockMu.Unlock()
+	c.clock = clock
+}
+
+// now returns the current time from the controller's clock
+func (c *Controller) now() time.Time {
+	c.clockMu.RLock()
+	defer c.clockMu.RUnlock()
+	return c.clock.Now()
+}
 
 // SetReadyCallback sets a callback function to be called when Faro is fully initialized and ready
 func (c *Controller) SetReadyCallback(callback func()) {
@@ -486,24 +1240,441 @@ func (c *Controller) SetReadyCallback(callback func()) {
 	}
 }
 
-// IsReady returns true if Faro is fully initialized and ready to process events
-func (c *Controller) IsReady() bool {
-	c.readyMu.Lock()
+// SetShutdownCallback registers a callback run synchronously at the start of Stop, before
+// informers are cancelled, so embedding apps can flush their own state. Each call adds
+// another callback; they run in registration order.
+func (c *Controller) SetShutdownCallback(callback func()) {
+	c.shutdownMu.Lock()
+	defer c.shutdownMu.Unlock()
+	c.onShutdown = append(c.onShutdown, callback)
+}
+
+// SetCRDDiscoveredCallback sets a callback fired when a CRD created at runtime serves a GVR
+// that matches the configuration (requires WatchCRDs). Informers for the GVR are started
+// before the callback runs.
+func (c *Controller) SetCRDDiscoveredCallback(callback func(crdName, gvrString string)) {
+	c.crdCallbackMu.Lock()
+	defer c.crdCallbackMu.Unlock()
+	c.onCRDDiscovered = callback
+}
+
+// SetInformerFailedCallback sets a callback fired when an informer is stopped by the
+// MaxInformerRestarts circuit breaker. Other informers keep running.
+func (c *Controller) SetInformerFailedCallback(callback func(gvrString, namespace string, err error)) {
+	c.informerFailedMu.Lock()
+	defer c.informerFailedMu.Unlock()
+	c.onInformerFailed = callback
+}
+
+// SetUIDStore sets an external store that UIDs are written through to, used to resolve
+// DELETED events for objects missing from the in-memory caches. Call it before Start.
+func (c *Controller) SetUIDStore(store UIDStore) {
+	c.uidStoreMu.Lock()
+	defer c.uidStoreMu.Unlock()
+	c.uidStore = store
+}
+
+// IsReady returns true if Faro is fully initialized and ready to process events
+func (c *Controller) IsReady() bool {
+	c.readyMu.Lock()
 	defer c.readyMu.Unlock()
 	c.logger.Debug("controller", fmt.Sprintf("Readiness check: %t", c.isReady))
 	return c.isReady
 }
 
+// Metrics returns the controller's metrics collector, e.g. to read counter values in tests
+// without scraping the metrics endpoint
+func (c *Controller) Metrics() *MetricsCollector {
+	return c.metrics
+}
+
+// Pause stops queueing events for delivery while informers keep running, e.g. during a
+// maintenance window. UID caches stay current, so deletes after Resume still resolve UIDs.
+// Events observed while paused are dropped, not replayed.
+func (c *Controller) Pause() {
+	if c.paused.Swap(true) {
+		return
+	}
+	c.metrics.SetControllerPaused(true)
+	c.logger.Info("controller", "Event delivery paused")
+}
+
+// Resume restarts event delivery after Pause
+func (c *Controller) Resume() {
+	if !c.paused.Swap(false) {
+		return
+	}
+	c.metrics.SetControllerPaused(false)
+	c.logger.Info("controller", "Event delivery resumed")
+}
+
+// Paused reports whether event delivery is paused
+func (c *Controller) Paused() bool {
+	return c.paused.Load()
+}
+
+// Healthy reports whether the controller is running: started and not yet stopped
+func (c *Controller) Healthy() bool {
+	return c.state.Load() == controllerStateStarted && c.ctx.Err() == nil
+}
+
+// Ready reports whether the controller is healthy, has finished Start and every informer
+// has completed its initial list. Backs the metrics server's /ready endpoint.
+func (c *Controller) Ready() bool {
+	c.readyMu.Lock()
+	started := c.isReady
+	c.readyMu.Unlock()
+	if !started || !c.Healthy() {
+		return false
+	}
+
+	// Informers are started asynchronously - one without a tracker yet hasn't synced either
+	synced := true
+	c.activeInformers.Range(func(key, value interface{}) bool {
+		if listerKey, ok := value.(string); ok {
+			if _, exists := c.informerTrackers.Load(listerKey); !exists {
+				synced = false
+			}
+		}
+		return synced
+	})
+	c.informerTrackers.Range(func(key, value interface{}) bool {
+		if !value.(*InformerStateTracker).hasSynced() {
+			synced = false
+		}
+		return synced
+	})
+	return synced
+}
+
 // AddResources dynamically adds new resource configurations to the controller
 func (c *Controller) AddResources(newResources []ResourceConfig) {
+	c.resourcesMu.Lock()
 	c.config.Resources = append(c.config.Resources, newResources...)
+	c.resourcesMu.Unlock()
 	c.logger.Info("controller", fmt.Sprintf("Added %d new resource configurations", len(newResources)))
 }
 
+// WaitForNamespaces blocks until every named namespace exists or ctx expires, for controllers
+// started before the namespaces they watch are created (e.g. hosted control planes). Call it
+// before AddResourcesAndWait so the informers of those namespaces are only expected to sync
+// once there is something to list. It runs its own namespace informer, stopped on return.
+func (c *Controller) WaitForNamespaces(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(c.client.DynamicClient(), 0, "", nil)
+	namespaceInformer := factory.ForResource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}).Informer()
+	changed := make(chan struct{}, 1)
+	notify := func(interface{}) {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{AddFunc: notify})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go namespaceInformer.Run(stopCh)
+	if !cache.WaitForCacheSync(ctx.Done(), namespaceInformer.HasSynced) {
+		return fmt.Errorf("namespace informer did not sync: %w", ctx.Err())
+	}
+
+	for {
+		var missing []string
+		for _, name := range names {
+			if _, exists, _ := namespaceInformer.GetStore().GetByKey(name); !exists {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) == 0 {
+			c.logger.Info("controller", fmt.Sprintf("Namespaces present: %s", strings.Join(names, ", ")))
+			return nil
+		}
+		c.logger.Debug("controller", fmt.Sprintf("Waiting for namespaces: %s", strings.Join(missing, ", ")))
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return fmt.Errorf("namespaces %s do not exist: %w", strings.Join(missing, ", "), ctx.Err())
+		}
+	}
+}
+
+// AddResourcesAndWait adds resource configurations, starts their informers and blocks until
+// every new informer has completed its initial sync or ctx expires. The controller must be started.
+func (c *Controller) AddResourcesAndWait(ctx context.Context, newResources []ResourceConfig) error {
+	if c.state.Load() != controllerStateStarted {
+		return errors.New("controller must be started before adding resources")
+	}
+
+	// Check the GVRs up front so unknown ones fail before anything starts
+	for _, resConfig := range newResources {
+		gvrString := resConfig.GVR
+		if resConfig.PreferredVersion {
+			if preferred, ok := c.resolvePreferredVersion(gvrString); ok {
+				gvrString = preferred
+			}
+		}
+
+		c.discoveredResourcesMu.RLock()
+		resourceInfo, found := c.discoveredResources[gvrString]
+		c.discoveredResourcesMu.RUnlock()
+		if !found {
+			return fmt.Errorf("resource %s not found in discovery results", resConfig.GVR)
+		}
+		if !resourceInfo.Watchable {
+			return fmt.Errorf("resource %s does not support both list and watch verbs and cannot be monitored", resConfig.GVR)
+		}
+	}
+
+	c.AddResources(newResources)
+	c.logger.Info("controller", "Starting informers for configured GVRs")
+	informerKeys, watchers, err := c.startConfigDrivenInformers(false)
+	if err != nil {
+		return err
+	}
+
+	// Informers of namespaces selected by label are only known once their watcher handled the
+	// initial namespace list
+	for _, watcher := range watchers {
+		select {
+		case <-watcher.synced:
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the namespaces matching %q: %w", watcher.selector, ctx.Err())
+		}
+		informerKeys = append(informerKeys, watcher.listerKeys()...)
+	}
+
+	if err := c.waitForInformersSynced(ctx, informerKeys); err != nil {
+		return err
+	}
+	c.logger.Info("controller", fmt.Sprintf("All %d new informers synced", len(informerKeys)))
+	return nil
+}
+
+// Reload applies a changed configuration, e.g. after the ConfigMap it was loaded from was
+// updated. Only Resources are reloaded: resource configs new in config get informers, as with
+// AddResourcesAndWait, while removing or changing a running one, or changing any other
+// setting, needs a restart and fails the reload. Every call is counted in
+// faro_config_reloads_total by result.
+func (c *Controller) Reload(ctx context.Context, config *Config) error {
+	err := c.reload(ctx, config)
+	c.metrics.OnConfigReload(err)
+	if err != nil {
+		c.logger.Error("controller", fmt.Sprintf("Config reload failed: %v", err))
+		return err
+	}
+	c.logger.Info("controller", "Config reload applied")
+	return nil
+}
+
+// reload validates config and starts the informers of its new resource configs
+func (c *Controller) reload(ctx context.Context, config *Config) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	running := c.configSnapshot()
+	if changed := changedSettings(running, config); len(changed) > 0 {
+		return fmt.Errorf("settings other than resources changed (%s), which requires a restart", strings.Join(changed, ", "))
+	}
+	for _, current := range running.Resources {
+		if !containsResourceConfig(config.Resources, current) {
+			return fmt.Errorf("resource %s was removed or changed, which requires a restart", current.GVR)
+		}
+	}
+
+	var added []ResourceConfig
+	for _, resConfig := range config.Resources {
+		if !containsResourceConfig(running.Resources, resConfig) {
+			added = append(added, resConfig)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	return c.AddResourcesAndWait(ctx, added)
+}
+
+// configSnapshot returns a shallow copy of the config with its own Resources slice, so it can
+// be read while AddResources appends to the live one
+func (c *Controller) configSnapshot() *Config {
+	c.resourcesMu.RLock()
+	defer c.resourcesMu.RUnlock()
+	snapshot := *c.config
+	snapshot.Resources = append([]ResourceConfig(nil), c.config.Resources...)
+	return &snapshot
+}
+
+// changedSettings returns the yaml names of the settings other than resources that differ
+// between current and updated
+func changedSettings(current, updated *Config) []string {
+	var changed []string
+	currentValue, updatedValue := reflect.ValueOf(*current), reflect.ValueOf(*updated)
+	for i := 0; i < currentValue.NumField(); i++ {
+		field := currentValue.Type().Field(i)
+		if field.Name == "Resources" {
+			continue
+		}
+		if reflect.DeepEqual(currentValue.Field(i).Interface(), updatedValue.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		changed = append(changed, name)
+	}
+	return changed
+}
+
+// containsResourceConfig reports whether configs holds a resource config equal to resConfig
+func containsResourceConfig(configs []ResourceConfig, resConfig ResourceConfig) bool {
+	for _, existing := range configs {
+		if reflect.DeepEqual(existing, resConfig) {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForInformersSynced blocks until the informers for all keys (GVR@namespace) have
+// completed their initial list, or ctx is done
+func (c *Controller) waitForInformersSynced(ctx context.Context, informerKeys []string) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		pending := 0
+		for _, key := range informerKeys {
+			trackerInterface, exists := c.informerTrackers.Load(key)
+			if !exists || !trackerInterface.(*InformerStateTracker).hasSynced() {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %d informer(s) to sync: %w", pending, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForPrioritySynced waits for the informers of a lower priority with PriorityWaitForSync.
+// An informer that never syncs (e.g. forbidden, or stopped by the circuit breaker) must not
+// hold up the rest, so after PrioritySyncTimeoutSec the next priority starts anyway; only
+// stopping the controller fails the wait.
+func (c *Controller) waitForPrioritySynced(informerKeys []string) error {
+	timeout := time.Duration(c.config.PrioritySyncTimeoutSec) * time.Second
+	if timeout <= 0 {
+		timeout = defaultPrioritySyncTimeout
+	}
+	ctx, cancel := context.WithTimeout(c.informerCtx, timeout)
+	defer cancel()
+
+	err := c.waitForInformersSynced(ctx, informerKeys)
+	if err != nil && c.informerCtx.Err() == nil {
+		c.logger.Warning("controller", fmt.Sprintf("Starting the next priority without waiting any longer: %v", err))
+		return nil
+	}
+	return err
+}
+
+// GetObject returns a copy of a tracked object from the informer cache.
+// Use an empty namespace for cluster-scoped resources.
+func (c *Controller) GetObject(gvrString, namespace, name string) (*unstructured.Unstructured, error) {
+	listerKey := gvrString + "@" + namespace
+	listerInterface, exists := c.listers.Load(listerKey)
+	if !exists {
+		return nil, fmt.Errorf("no informer for %s", listerKey)
+	}
+
+	key := name
+	if namespace != "" {
+		key = namespace + "/" + name
+	}
+	obj, err := listerInterface.(cache.GenericLister).Get(key)
+	if err != nil {
+		// With OR-ed LabelSelectors the object may only be cached by another selector's informer
+		c.listers.Range(func(otherKey, otherLister interface{}) bool {
+			if !strings.HasPrefix(otherKey.(string), listerKey+"|") {
+				return true
+			}
+			if found, getErr := otherLister.(cache.GenericLister).Get(key); getErr == nil {
+				obj, err = found, nil
+				return false
+			}
+			return true
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	unstructuredObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T for %s", obj, key)
+	}
+	return unstructuredObj.DeepCopy(), nil
+}
+
+// SnapshotAll writes every object currently held by the informer caches to w, one JSON line
+// per object in the JSONEvent shape with eventType "SNAPSHOT". JSON middleware and export
+// settings apply as for events. It reads the caches without blocking event processing, so
+// objects changing meanwhile may be written in either state; the event stream is unaffected.
+func (c *Controller) SnapshotAll(w io.Writer) error {
+	var listerKeys []string
+	c.listers.Range(func(key, _ interface{}) bool {
+		listerKeys = append(listerKeys, key.(string))
+		return true
+	})
+	sort.Strings(listerKeys)
+
+	encoder := json.NewEncoder(w)
+	written := make(map[string]bool) // gvr|uid, objects cached by several informers are written once
+	for _, listerKey := range listerKeys {
+		listerInterface, exists := c.listers.Load(listerKey)
+		if !exists {
+			continue // Informer stopped meanwhile
+		}
+		gvrString := strings.SplitN(listerKey, "@", 2)[0]
+		objects, err := listerInterface.(cache.GenericLister).List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", listerKey, err)
+		}
+		for _, obj := range objects {
+			unstructuredObj, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			seenKey := gvrString + "|" + string(unstructuredObj.GetUID())
+			if written[seenKey] {
+				continue
+			}
+			written[seenKey] = true
+
+			jsonEvent, ok := c.buildJSONEvent("SNAPSHOT", gvrString, unstructuredObj.GetNamespace(), unstructuredObj.GetName(), string(unstructuredObj.GetUID()), unstructuredObj.GetLabels(), unstructuredObj)
+			if !ok {
+				continue
+			}
+			if err := encoder.Encode(jsonEvent); err != nil {
+				return fmt.Errorf("failed to write snapshot of %s: %w", listerKey, err)
+			}
+		}
+	}
+	return nil
+}
+
 // StartInformers starts informers for configured GVRs
 func (c *Controller) StartInformers() error {
 	c.logger.Info("controller", "Starting informers for configured GVRs")
-	return c.startConfigDrivenInformers()
+	_, _, err := c.startConfigDrivenInformers(false)
+	return err
 }
 
 
@@ -531,9 +1702,11 @@ func (c *Controller) createEventHandlers(handlerFunc func(string, *unstructured.
 			
 			// Handle Kubernetes cache tombstone objects properly
 			if tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown); isTombstone {
-				unstructuredObj, ok = tombstone.Obj.(*unstructured.Unstructured)
-				if !ok {
-					c.logger.Error("controller", fmt.Sprintf("Tombstone contained unexpected object type for %s", gvrString))
+				var result string
+				unstructuredObj, result = DeletedObjectFromTombstone(tombstone)
+				c.metrics.OnTombstoneEvent(gvrString, result)
+				if unstructuredObj == nil {
+					c.logger.Error("controller", fmt.Sprintf("Dropping tombstone with unexpected object type %T and key %q for %s", tombstone.Obj, tombstone.Key, gvrString))
 					return
 				}
 			} else {
@@ -560,7 +1733,12 @@ func (c *Controller) runInformerWithLogging(informer cache.SharedIndexInformer,
 
 
 // Start initializes and starts the multi-layered informer architecture
+// A controller can only be started once; further calls return ErrAlreadyStarted.
 func (c *Controller) Start() error {
+	if !c.state.CompareAndSwap(controllerStateNew, controllerStateStarted) {
+		return ErrAlreadyStarted
+	}
+
 	c.logger.Info("controller", "Starting sophisticated multi-layered informer controller")
 
 	// Start worker goroutines for processing work queue
@@ -570,17 +1748,38 @@ func (c *Controller) Start() error {
 	}
 
 	// 1. Discover all available API resources in the cluster
-	if err := c.discoverAPIResources(); err != nil {
+	if err := c.discoverWithRetries(); err != nil {
 		return fmt.Errorf("failed to discover API resources: %w", err)
 	}
 
-	// 2. Start informers based on configuration and discovery results
+	// 2. Optionally verify RBAC permissions before any informer starts
+	if c.config.PreflightRBACCheck {
+		if err := c.preflightRBACCheck(); err != nil {
+			return fmt.Errorf("RBAC preflight check failed: %w", err)
+		}
+	}
+
+	// 3. Start informers based on configuration and discovery results
 	c.logger.Info("controller", "Starting informers for configured GVRs")
-	if err := c.startConfigDrivenInformers(); err != nil {
+	if _, _, err := c.startConfigDrivenInformers(c.config.PriorityWaitForSync); err != nil {
 		return fmt.Errorf("failed to start informers: %w", err)
 	}
 
-	// CRD watching removed - library users should implement CRD discovery if needed
+	// Keep retrying group versions that were unavailable (e.g. aggregated APIs) in the background
+	c.discoveredResourcesMu.RLock()
+	unavailable := len(c.unavailableGroupVersions)
+	c.discoveredResourcesMu.RUnlock()
+	if unavailable > 0 {
+		c.wg.Add(1)
+		go c.retryUnavailableAPIServices()
+	}
+
+	// 4. Optionally watch for CRDs created at runtime that match the configuration
+	if c.config.WatchCRDs {
+		if err := c.startCRDWatcher(); err != nil {
+			return fmt.Errorf("failed to start CRD watcher: %w", err)
+		}
+	}
 
 	c.logger.Info("controller", "Multi-layered informer architecture started successfully")
 	
@@ -597,44 +1796,519 @@ func (c *Controller) Start() error {
 	return nil
 }
 
-// discoverAPIResources discovers all available API resources and categorizes them
-func (c *Controller) discoverAPIResources() error {
+// defaultPrioritySyncTimeout bounds each PriorityWaitForSync wait when PrioritySyncTimeoutSec is unset
+const defaultPrioritySyncTimeout = time.Minute
+
+// defaultInformerRestartWindow is the failure counting window when InformerRestartWindowSec is unset
+const defaultInformerRestartWindow = 5 * time.Minute
+
+// defaultDiscoveryWorkers bounds concurrent group/version discovery calls when DiscoveryWorkers is unset
+const defaultDiscoveryWorkers = 10
+
+// defaultDiscoveryRetryBackoff is the wait before the first discovery retry when DiscoveryRetryBackoffMs is unset
+const defaultDiscoveryRetryBackoff = time.Second
+
+// maxDiscoveryRetryBackoff caps the doubling wait between discovery retries
+const maxDiscoveryRetryBackoff = 30 * time.Second
+
+// discoverWithRetries runs discoverAPIResources for Start, retrying up to DiscoveryRetries
+// times with exponential backoff so an API server that is briefly unavailable (cluster boot,
+// control-plane rollout) does not fail Start. DiscoveryTimeoutSec bounds each attempt.
+func (c *Controller) discoverWithRetries() error {
+	backoff := time.Duration(c.config.DiscoveryRetryBackoffMs) * time.Millisecond
+	if backoff <= 0 {
+		backoff = defaultDiscoveryRetryBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		discoveryCtx, cancel := c.ctx, context.CancelFunc(func() {})
+		if c.config.DiscoveryTimeoutSec > 0 {
+			discoveryCtx, cancel = context.WithTimeout(c.ctx, time.Duration(c.config.DiscoveryTimeoutSec)*time.Second)
+		}
+		err := c.discoverAPIResources(discoveryCtx)
+		cancel()
+		if err == nil || attempt >= c.config.DiscoveryRetries || c.ctx.Err() != nil {
+			return err
+		}
+
+		c.logger.Warning("controller", fmt.Sprintf("API discovery failed (attempt %d of %d), retrying in %v: %v",
+			attempt+1, c.config.DiscoveryRetries+1, backoff, err))
+		select {
+		case <-time.After(backoff):
+		case <-c.ctx.Done():
+			return err
+		}
+		backoff = min(backoff*2, maxDiscoveryRetryBackoff)
+	}
+}
+
+// discoverAPIResources discovers all available API resources, from the shared DiscoveryCache
+// when the controller has one (NewControllerWithDiscovery) and the API server otherwise
+func (c *Controller) discoverAPIResources(ctx context.Context) error {
+	if c.discoveryCache == nil {
+		return c.discoverFromAPIServer(ctx)
+	}
+
+	result, err := c.discoveryCache.get(ctx, func() (*discoveryResult, error) {
+		if err := c.discoverFromAPIServer(ctx); err != nil {
+			return nil, err
+		}
+		c.discoveredResourcesMu.RLock()
+		defer c.discoveredResourcesMu.RUnlock()
+		result := &discoveryResult{
+			resources:   make(map[string]ResourceInfo, len(c.discoveredResources)),
+			preferred:   make(map[string]string, len(c.preferredVersions)),
+			unavailable: make(map[schema.GroupVersion]error, len(c.unavailableGroupVersions)),
+		}
+		for gvrKey, info := range c.discoveredResources {
+			result.resources[gvrKey] = *info
+		}
+		for group, version := range c.preferredVersions {
+			result.preferred[group] = version
+		}
+		for gv, reason := range c.unavailableGroupVersions {
+			result.unavailable[gv] = reason
+		}
+		return result, nil
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return discoveryContextError(ctx, ctxErr)
+		}
+		return err
+	}
+
+	// Each controller gets its own copies: runtime CRD discovery adds to them per controller,
+	// and each retries the unavailable group versions itself
+	c.discoveredResourcesMu.Lock()
+	for gvrKey, info := range result.resources {
+		info := info
+		c.addDiscoveredResource(gvrKey, &info)
+	}
+	c.preferredVersions = make(map[string]string, len(result.preferred))
+	for group, version := range result.preferred {
+		c.preferredVersions[group] = version
+	}
+	c.unavailableGroupVersions = make(map[schema.GroupVersion]error, len(result.unavailable))
+	for gv, reason := range result.unavailable {
+		c.unavailableGroupVersions[gv] = reason
+	}
+	c.discoveredResourcesMu.Unlock()
+
+	resourceCount := c.updateDiscoveryMetrics()
+	c.updateAPIServiceMetrics()
+	c.logger.Info("controller", fmt.Sprintf("Discovery loaded from shared cache: %d resources", resourceCount))
+	return nil
+}
+
+// discoverFromAPIServer queries the API server for all available API resources and categorizes
+// them. Discovery calls are abandoned as soon as ctx is done so a degraded API server
+// cannot wedge Start.
+func (c *Controller) discoverFromAPIServer(ctx context.Context) error {
 	c.logger.Info("controller", "Discovering API resources")
 
 	// Get API groups
-	apiGroups, err := c.client.Discovery.ServerGroups()
+	apiGroups, err := callWithContext(ctx, c.client.DiscoveryLister().ServerGroups)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return discoveryContextError(ctx, ctxErr)
+		}
 		return fmt.Errorf("failed to discover API groups: %w", err)
 	}
 
 	c.logger.Info("controller", fmt.Sprintf("Found %d API groups", len(apiGroups.Groups)))
 
+	// Remember each group's preferred version for PreferredVersion resources
+	c.discoveredResourcesMu.Lock()
+	c.preferredVersions = make(map[string]string, len(apiGroups.Groups))
+	for _, group := range apiGroups.Groups {
+		c.preferredVersions[group.Name] = group.PreferredVersion.Version
+	}
+	c.unavailableGroupVersions = make(map[schema.GroupVersion]error)
+	c.discoveredResourcesMu.Unlock()
+
 	// Process core API group (v1)
-	if err := c.processAPIGroup("", "v1"); err != nil {
+	if err := c.processAPIGroup(ctx, "", "v1"); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return discoveryContextError(ctx, ctxErr)
+		}
+		// Nearly every configuration watches core resources, so by default a failure here
+		// fails discovery instead of leaving ConfigMaps, Secrets etc. silently unwatched
+		if c.config.RequireCoreGroup == nil || *c.config.RequireCoreGroup {
+			return fmt.Errorf("failed to process core API group: %w", err)
+		}
 		c.logger.Warning("controller", fmt.Sprintf("Failed to process core API group: %v", err))
 	}
 
-	// Process other API groups - discover ALL versions, not just preferred
-	for _, group := range apiGroups.Groups {
-		c.logger.Debug("controller", fmt.Sprintf("Processing API group %s with %d versions", group.Name, len(group.Versions)))
+	// Process other API groups - discover ALL versions, not just preferred.
+	// Group versions are fetched in parallel by a bounded pool; processAPIGroup guards
+	// discoveredResources and never overwrites an existing GVR.
+	workers := c.config.DiscoveryWorkers
+	if workers <= 0 {
+		workers = defaultDiscoveryWorkers
+	}
+	semaphore := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+groups:
+	for _, group := range apiGroups.Groups {
+		c.logger.Debug("controller", fmt.Sprintf("Processing API group %s with %d versions", group.Name, len(group.Versions)))
+
+		// Process ALL versions of this group to catch all CRDs
+		for _, version := range group.Versions {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				break groups
+			}
+
+			wg.Add(1)
+			go func(groupName, versionName string) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				if err := c.processAPIGroup(ctx, groupName, versionName); err != nil {
+					c.logger.Debug("controller", fmt.Sprintf("Failed to process API group %s/%s: %v", groupName, versionName, err))
+					if ctx.Err() == nil {
+						c.markGroupVersionUnavailable(schema.GroupVersion{Group: groupName, Version: versionName}, err)
+					}
+				}
+			}(group.Name, version.Version)
+		}
+	}
+	wg.Wait()
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return discoveryContextError(ctx, ctxErr)
+	}
+
+	resourceCount := c.updateDiscoveryMetrics()
+	c.updateAPIServiceMetrics()
+	c.logger.Info("controller", fmt.Sprintf("Discovery completed: %d resources found", resourceCount))
+	return nil
+}
+
+// defaultAPIServiceRetryInterval is how often unavailable group versions are retried when APIServiceRetrySec is unset
+const defaultAPIServiceRetryInterval = 30 * time.Second
+
+// markGroupVersionUnavailable records a group version whose discovery failed. Its resources are
+// reported as degraded and skipped until retryUnavailableAPIServices gets an answer.
+func (c *Controller) markGroupVersionUnavailable(gv schema.GroupVersion, err error) {
+	c.discoveredResourcesMu.Lock()
+	c.unavailableGroupVersions[gv] = err
+	c.discoveredResourcesMu.Unlock()
+	c.logger.Warning("controller", fmt.Sprintf("API group version %s is unavailable, its resources are degraded: %v", gv, err))
+}
+
+// unavailableReason returns the discovery error of the group version serving gvrString, or nil
+// when that group version is available
+func (c *Controller) unavailableReason(gvrString string) error {
+	slash := strings.LastIndex(gvrString, "/")
+	if slash < 0 {
+		return nil
+	}
+	gv, err := schema.ParseGroupVersion(gvrString[:slash])
+	if err != nil {
+		return nil
+	}
+	c.discoveredResourcesMu.RLock()
+	defer c.discoveredResourcesMu.RUnlock()
+	return c.unavailableGroupVersions[gv]
+}
+
+// updateAPIServiceMetrics publishes faro_apiservice_available for every discovered group
+func (c *Controller) updateAPIServiceMetrics() {
+	c.discoveredResourcesMu.RLock()
+	available := make(map[string]bool, len(c.preferredVersions))
+	for group := range c.preferredVersions {
+		available[group] = true
+	}
+	for gv := range c.unavailableGroupVersions {
+		available[gv.Group] = false
+	}
+	c.discoveredResourcesMu.RUnlock()
+
+	for group, ok := range available {
+		if group != "" {
+			c.metrics.SetAPIServiceAvailable(group, ok)
+		}
+	}
+}
+
+// retryUnavailableAPIServices periodically retries discovery of unavailable group versions and
+// starts the informers of configured resources they serve once they answer. It returns when
+// every group version is available again or the controller stops.
+func (c *Controller) retryUnavailableAPIServices() {
+	defer c.wg.Done()
+
+	interval := time.Duration(c.config.APIServiceRetrySec) * time.Second
+	if interval <= 0 {
+		interval = defaultAPIServiceRetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.informerCtx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		c.discoveredResourcesMu.RLock()
+		pending := make([]schema.GroupVersion, 0, len(c.unavailableGroupVersions))
+		for gv := range c.unavailableGroupVersions {
+			pending = append(pending, gv)
+		}
+		c.discoveredResourcesMu.RUnlock()
+		if len(pending) == 0 {
+			return
+		}
+
+		recovered := false
+		for _, gv := range pending {
+			if err := c.processAPIGroup(c.informerCtx, gv.Group, gv.Version); err != nil {
+				c.logger.Debug("controller", fmt.Sprintf("API group version %s is still unavailable: %v", gv, err))
+				continue
+			}
+			c.discoveredResourcesMu.Lock()
+			delete(c.unavailableGroupVersions, gv)
+			c.discoveredResourcesMu.Unlock()
+			c.logger.Info("controller", fmt.Sprintf("API group version %s is available again", gv))
+			recovered = true
+		}
+		if !recovered {
+			continue
+		}
+
+		c.updateDiscoveryMetrics()
+		c.updateAPIServiceMetrics()
+		if _, _, err := c.startConfigDrivenInformers(false); err != nil {
+			c.logger.Error("controller", fmt.Sprintf("Failed to start informers for recovered API group versions: %v", err))
+		}
+	}
+}
+
+// updateDiscoveryMetrics publishes the discovered resource and group counts and returns the resource count
+func (c *Controller) updateDiscoveryMetrics() int {
+	c.discoveredResourcesMu.RLock()
+	resourceCount := len(c.discoveredResources)
+	groups := make(map[string]bool)
+	for _, info := range c.discoveredResources {
+		groups[info.Group] = true
+	}
+	c.discoveredResourcesMu.RUnlock()
+
+	c.metrics.SetDiscoveredResources(resourceCount, len(groups))
+	return resourceCount
+}
+
+// addDiscoveredResource records info under gvrKey unless the GVR is already known and reports
+// whether it was added. Its Kind is indexed for resourceForKind, except for subresources
+// (e.g. pods/status), which share their parent's Kind. Callers hold discoveredResourcesMu.
+func (c *Controller) addDiscoveredResource(gvrKey string, info *ResourceInfo) bool {
+	if _, exists := c.discoveredResources[gvrKey]; exists {
+		return false
+	}
+	c.discoveredResources[gvrKey] = info
+	if !strings.Contains(info.Resource, "/") {
+		gvk := schema.GroupVersionKind{Group: info.Group, Version: info.Version, Kind: info.Kind}
+		if _, indexed := c.discoveredKinds[gvk]; !indexed {
+			c.discoveredKinds[gvk] = gvrKey
+		}
+	}
+	return true
+}
+
+// removeDiscoveredResource forgets gvrKey and its Kind index entry and reports whether it was
+// known. Callers hold discoveredResourcesMu.
+func (c *Controller) removeDiscoveredResource(gvrKey string) bool {
+	info, exists := c.discoveredResources[gvrKey]
+	if !exists {
+		return false
+	}
+	delete(c.discoveredResources, gvrKey)
+	gvk := schema.GroupVersionKind{Group: info.Group, Version: info.Version, Kind: info.Kind}
+	if c.discoveredKinds[gvk] == gvrKey {
+		delete(c.discoveredKinds, gvk)
+	}
+	return true
+}
+
+// resourceForKind returns the discovered GVR serving gvk, e.g. to resolve an owner or
+// involvedObject reference, with its ResourceInfo
+func (c *Controller) resourceForKind(gvk schema.GroupVersionKind) (string, ResourceInfo, bool) {
+	c.discoveredResourcesMu.RLock()
+	defer c.discoveredResourcesMu.RUnlock()
+	gvrString, found := c.discoveredKinds[gvk]
+	if !found {
+		return "", ResourceInfo{}, false
+	}
+	return gvrString, *c.discoveredResources[gvrString], true
+}
+
+// normalizeConfig normalizes the configuration and resolves the version of PreferredVersion
+// resources from discovery, so the result is keyed by the GVRs informers are started for
+func (c *Controller) normalizeConfig() (map[string][]NormalizedConfig, error) {
+	normalizedGVRs, err := c.configSnapshot().Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string][]NormalizedConfig, len(normalizedGVRs))
+	for gvrString, configs := range normalizedGVRs {
+		for _, config := range configs {
+			if config.PreferredVersion {
+				if preferred, ok := c.resolvePreferredVersion(gvrString); ok {
+					c.logger.Debug("controller", fmt.Sprintf("Resolved %s to preferred version %s", gvrString, preferred))
+					config.GVR = preferred
+				}
+			}
+			resolved[config.GVR] = append(resolved[config.GVR], config)
+		}
+	}
+	return resolved, nil
+}
+
+// resolvePreferredVersion returns the GVR string of gvrString's group and resource at the
+// group's preferred version when discovery found the resource there, otherwise at the highest
+// (Kubernetes version order) version serving it. The version in gvrString is ignored and may
+// be empty ("example.com//widgets"). ok is false when no discovered version serves it.
+func (c *Controller) resolvePreferredVersion(gvrString string) (string, bool) {
+	group, _, resource, ok := splitGVR(gvrString)
+	if !ok {
+		return "", false
+	}
+	gvrKey := func(version string) string {
+		if group == "" {
+			return fmt.Sprintf("%s/%s", version, resource)
+		}
+		return fmt.Sprintf("%s/%s/%s", group, version, resource)
+	}
+
+	c.discoveredResourcesMu.RLock()
+	defer c.discoveredResourcesMu.RUnlock()
+
+	if preferred := c.preferredVersions[group]; preferred != "" {
+		if _, found := c.discoveredResources[gvrKey(preferred)]; found {
+			return gvrKey(preferred), true
+		}
+	}
+	best := ""
+	for _, info := range c.discoveredResources {
+		if info.Group == group && info.Resource == resource && (best == "" || kubeversion.CompareKubeAwareVersionStrings(info.Version, best) > 0) {
+			best = info.Version
+		}
+	}
+	if best == "" {
+		return "", false
+	}
+	return gvrKey(best), true
+}
+
+// preflightRBACCheck issues a minimal list for every configured GVR+namespace and reports
+// the ones the service account is not allowed to access. Informers for denied targets would
+// otherwise fail silently at runtime with no events delivered.
+func (c *Controller) preflightRBACCheck() error {
+	c.logger.Info("controller", "Running RBAC preflight check for configured GVRs")
+
+	normalizedGVRs, err := c.normalizeConfig()
+	if err != nil {
+		return fmt.Errorf("failed to normalize configuration: %w", err)
+	}
+
+	var denied []string
+	for gvrString, normalizedConfigs := range normalizedGVRs {
+		c.discoveredResourcesMu.RLock()
+		resourceInfo, found := c.discoveredResources[gvrString]
+		c.discoveredResourcesMu.RUnlock()
+		if !found {
+			continue // Reported later by startConfigDrivenInformers
+		}
+
+		gvr := schema.GroupVersionResource{
+			Group:    resourceInfo.Group,
+			Version:  resourceInfo.Version,
+			Resource: resourceInfo.Resource,
+		}
+
+		// Collect the namespaces informers will be started for
+		namespaces := make(map[string]bool)
+		for _, config := range normalizedConfigs {
+			if !resourceInfo.Namespaced {
+				namespaces[""] = true
+				continue
+			}
+			for _, ns := range config.NamespaceNames {
+				namespaces[ns] = true
+			}
+		}
+
+		for namespace := range namespaces {
+			_, err := c.client.DynamicClient().Resource(gvr).Namespace(namespace).List(c.ctx, metav1.ListOptions{Limit: 1})
+			if err == nil {
+				continue
+			}
+			target := gvrString + "@" + namespace
+			if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+				denied = append(denied, target)
+				c.logger.Error("controller", fmt.Sprintf("RBAC preflight: access denied for %s: %v", target, err))
+			} else {
+				c.logger.Warning("controller", fmt.Sprintf("RBAC preflight: could not verify access for %s: %v", target, err))
+			}
+		}
+	}
+
+	if len(denied) == 0 {
+		c.logger.Info("controller", "RBAC preflight check passed")
+		return nil
+	}
+
+	sort.Strings(denied)
+	if c.config.PreflightRBACFailFast {
+		return fmt.Errorf("access denied for %d target(s): %s", len(denied), strings.Join(denied, ", "))
+	}
+
+	c.logger.Warning("controller", fmt.Sprintf("RBAC preflight: %d target(s) denied, informers for them will not receive events: %s", len(denied), strings.Join(denied, ", ")))
+	return nil
+}
+
+// callWithContext runs a blocking discovery call, returning ctx.Err() as soon as ctx is done.
+// The discovery client has no context support, so an abandoned call finishes in the background.
+func callWithContext[T any](ctx context.Context, call func() (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		value, err := call()
+		done <- result{value: value, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
 
-		// Process ALL versions of this group to catch all CRDs
-		for _, version := range group.Versions {
-			if err := c.processAPIGroup(group.Name, version.Version); err != nil {
-				c.logger.Debug("controller", fmt.Sprintf("Failed to process API group %s/%s: %v", group.Name, version.Version, err))
-			}
+// discoveryContextError describes why discovery was aborted
+func discoveryContextError(ctx context.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		if deadline, ok := ctx.Deadline(); ok {
+			return fmt.Errorf("API discovery timed out (deadline %s): %w", deadline.Format(time.RFC3339), err)
 		}
+		return fmt.Errorf("API discovery timed out: %w", err)
 	}
-
-	c.discoveredResourcesMu.RLock()
-	resourceCount := len(c.discoveredResources)
-	c.discoveredResourcesMu.RUnlock()
-	c.logger.Info("controller", fmt.Sprintf("Discovery completed: %d resources found", resourceCount))
-	return nil
+	return fmt.Errorf("API discovery cancelled: %w", err)
 }
 
 // processAPIGroup processes a single API group and stores resource information
-func (c *Controller) processAPIGroup(group, version string) error {
+func (c *Controller) processAPIGroup(ctx context.Context, group, version string) error {
 	var groupVersion string
 	if group == "" {
 		groupVersion = version // Core API
@@ -642,7 +2316,9 @@ func (c *Controller) processAPIGroup(group, version string) error {
 		groupVersion = fmt.Sprintf("%s/%s", group, version)
 	}
 
-	resources, err := c.client.Discovery.ServerResourcesForGroupVersion(groupVersion)
+	resources, err := callWithContext(ctx, func() (*metav1.APIResourceList, error) {
+		return c.client.DiscoveryLister().ServerResourcesForGroupVersion(groupVersion)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get resources for %s: %w", groupVersion, err)
 	}
@@ -666,14 +2342,14 @@ func (c *Controller) processAPIGroup(group, version string) error {
 			Resource:   resource.Name,
 			Kind:       resource.Kind,
 			Namespaced: resource.Namespaced,
+			Watchable:  c.isResourceWatchable(resource),
 		}
 
 		// Avoid overwriting if we already have this exact GVR (from previous version processing)
 		c.discoveredResourcesMu.Lock()
-		if _, exists := c.discoveredResources[gvrKey]; !exists {
-			c.discoveredResources[gvrKey] = resourceInfo
-			c.logger.Debug("controller", fmt.Sprintf("Discovered resource: %s (Kind: %s, Namespaced: %t)",
-				gvrKey, resource.Kind, resource.Namespaced))
+		if c.addDiscoveredResource(gvrKey, resourceInfo) {
+			c.logger.Debug("controller", fmt.Sprintf("Discovered resource: %s (Kind: %s, Namespaced: %t, Watchable: %t)",
+				gvrKey, resource.Kind, resource.Namespaced, resourceInfo.Watchable))
 		}
 		c.discoveredResourcesMu.Unlock()
 	}
@@ -681,17 +2357,18 @@ func (c *Controller) processAPIGroup(group, version string) error {
 	return nil
 }
 
-// isResourceWatchable checks if a resource supports watch or list operations
+// isResourceWatchable checks if a resource supports both list and watch, which informers need
 func (c *Controller) isResourceWatchable(resource metav1.APIResource) bool {
-	// Loop over resource verbs and return true if "watch" or "list" is found
+	canList, canWatch := false, false
 	for _, verb := range resource.Verbs {
-		if verb == "watch" || verb == "list" {
-			return true
+		switch verb {
+		case "list":
+			canList = true
+		case "watch":
+			canWatch = true
 		}
 	}
-	
-	// If we reach here, neither "watch" nor "list" verb was found
-	return false
+	return canList && canWatch
 }
 
 // startCRDWatcher starts a CRD informer to watch for new CustomResourceDefinitions
@@ -700,7 +2377,7 @@ func (c *Controller) startCRDWatcher() error {
 
 	// Create factory for CRD resources (cluster-scoped, no namespace filter) - pure event-driven, no resync needed
 	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
-		c.client.Dynamic, 0, "", nil)
+		c.client.DynamicClient(), 0, "", nil)
 
 	crdGVR := schema.GroupVersionResource{
 		Group:    "apiextensions.k8s.io",
@@ -758,7 +2435,7 @@ func (c *Controller) startCRDWatcher() error {
 	go func() {
 		defer c.wg.Done()
 		c.logger.Info("controller", "Running dynamic CRD discovery informer")
-		crdInformer.Run(c.ctx.Done())
+		crdInformer.Run(c.informerCtx.Done())
 		c.logger.Info("controller", "Dynamic CRD discovery informer stopped")
 	}()
 
@@ -787,29 +2464,80 @@ func (c *Controller) handleCRDAdded(crdUnstructured *unstructured.Unstructured)
 		return
 	}
 
+	if c.cancelCRDTeardown(&crd) {
+		c.logger.Info("controller", fmt.Sprintf("CRD %s re-added within crd_readd_debounce_sec, keeping its informers", crd.Name))
+	}
+
 	// Build GVR string for this CRD to check if it was already discovered
 	if len(crd.Spec.Versions) == 0 {
 		c.logger.Warning("controller", fmt.Sprintf("CRD %s has no versions, cannot process", crd.Name))
 		return
 	}
 
-	group := crd.Spec.Group
-	version := crd.Spec.Versions[0].Name
-	resource := crd.Spec.Names.Plural
-	gvrString := fmt.Sprintf("%s/%s/%s", group, version, resource)
+	// Register every served version so config entries for any of them can be matched
+	var registered []string
+	for _, version := range crd.Spec.Versions {
+		if !version.Served {
+			continue
+		}
+		gvrString := fmt.Sprintf("%s/%s/%s", crd.Spec.Group, version.Name, crd.Spec.Names.Plural)
 
-	// Check if this exact CRD (same group/version/resource) was already discovered during initial API discovery
-	c.discoveredResourcesMu.RLock()
-	_, alreadyDiscovered := c.discoveredResources[gvrString]
-	c.discoveredResourcesMu.RUnlock()
-	if alreadyDiscovered {
-		c.logger.Debug("controller", fmt.Sprintf("CRD %s (exact GVR: %s) already discovered during startup, skipping", crd.Name, gvrString))
+		// Check if this exact CRD (same group/version/resource) was already discovered during initial API discovery
+		c.discoveredResourcesMu.Lock()
+		alreadyDiscovered := !c.addDiscoveredResource(gvrString, &ResourceInfo{
+			Group:      crd.Spec.Group,
+			Version:    version.Name,
+			Resource:   crd.Spec.Names.Plural,
+			Kind:       crd.Spec.Names.Kind,
+			Namespaced: crd.Spec.Scope == apiextensionsv1.NamespaceScoped,
+			Watchable:  true, // Custom resources always support list and watch
+		})
+		c.discoveredResourcesMu.Unlock()
+		if alreadyDiscovered {
+			c.logger.Debug("controller", fmt.Sprintf("CRD %s (exact GVR: %s) already discovered, skipping", crd.Name, gvrString))
+			continue
+		}
+
+		c.logger.Info("controller", fmt.Sprintf("New CRD detected: %s (GVR: %s)", crd.Name, gvrString))
+		registered = append(registered, gvrString)
+	}
+
+	c.updateDiscoveryMetrics()
+	if len(registered) == 0 {
 		return
 	}
 
-	c.logger.Info("controller", fmt.Sprintf("New CRD detected: %s (GVR: %s)", crd.Name, gvrString))
+	// Normalize after registering, so PreferredVersion resources can resolve to the new versions
+	normalizedGVRs, err := c.normalizeConfig()
+	if err != nil {
+		c.logger.Error("controller", fmt.Sprintf("Failed to normalize configuration for CRD %s: %v", crd.Name, err))
+		return
+	}
+	var matched []string
+	for _, gvrString := range registered {
+		if _, configured := normalizedGVRs[gvrString]; configured {
+			matched = append(matched, gvrString)
+		}
+	}
+	if len(matched) == 0 {
+		return
+	}
+
+	// Start informers for the newly served GVRs; already active informers are skipped
+	c.logger.Info("controller", fmt.Sprintf("CRD %s matches configuration, starting informers for %s", crd.Name, strings.Join(matched, ", ")))
+	if _, _, err := c.startConfigDrivenInformers(false); err != nil {
+		c.logger.Error("controller", fmt.Sprintf("Failed to start informers for CRD %s: %v", crd.Name, err))
+		return
+	}
 
-	// CRD evaluation removed - library users should implement CRD discovery if needed
+	c.crdCallbackMu.RLock()
+	callback := c.onCRDDiscovered
+	c.crdCallbackMu.RUnlock()
+	if callback != nil {
+		for _, gvrString := range matched {
+			callback(crd.Name, gvrString)
+		}
+	}
 }
 
 // handleCRDUpdated processes CRD updates
@@ -831,7 +2559,7 @@ func (c *Controller) handleCRDUpdated(oldCRD, newCRD *unstructured.Unstructured)
 	// Check if the update affects our monitoring (GVR or scope changes)
 	if c.crdUpdateRequiresRestart(&oldCRDTyped, &newCRDTyped) {
 		c.logger.Info("controller", fmt.Sprintf("CRD %s update requires informer restart", newCRDTyped.Name))
-		c.handleCRDDeleted(oldCRD)
+		c.stopCRDInformer(&oldCRDTyped)
 		c.handleCRDAdded(newCRD)
 	} else {
 		c.logger.Debug("controller", fmt.Sprintf("CRD %s update doesn't affect monitoring, no restart needed", newCRDTyped.Name))
@@ -893,53 +2621,64 @@ func (c *Controller) handleCRDDeleted(crdUnstructured *unstructured.Unstructured
 		return
 	}
 
-	c.logger.Info("controller", fmt.Sprintf("CRD deleted: %s", crd.Name))
+	if c.config.CRDReAddDebounceSec <= 0 {
+		c.logger.Info("controller", fmt.Sprintf("CRD deleted: %s", crd.Name))
+		// Stop any running informers for this CRD
+		c.stopCRDInformer(&crd)
+		return
+	}
 
-	// Stop any running informers for this CRD
-	c.stopCRDInformer(&crd)
+	// Upgrades often delete and re-create a CRD; keep its informers until the window has passed
+	delay := time.Duration(c.config.CRDReAddDebounceSec) * time.Second
+	c.logger.Info("controller", fmt.Sprintf("CRD deleted: %s, stopping its informers in %s unless it is re-added", crd.Name, delay))
+	c.pendingCRDTeardownsMu.Lock()
+	defer c.pendingCRDTeardownsMu.Unlock()
+	if c.pendingCRDTeardowns == nil {
+		c.pendingCRDTeardowns = make(map[string]*pendingCRDTeardown)
+	}
+	if pending, ok := c.pendingCRDTeardowns[crd.Name]; ok {
+		pending.timer.Stop()
+	}
+	pending := &pendingCRDTeardown{crd: &crd}
+	pending.timer = time.AfterFunc(delay, func() {
+		c.pendingCRDTeardownsMu.Lock()
+		current := c.pendingCRDTeardowns[crd.Name] == pending
+		if current {
+			delete(c.pendingCRDTeardowns, crd.Name)
+		}
+		c.pendingCRDTeardownsMu.Unlock()
+		if current && c.informerCtx.Err() == nil {
+			c.stopCRDInformer(&crd)
+		}
+	})
+	c.pendingCRDTeardowns[crd.Name] = pending
 }
 
+// pendingCRDTeardown is a deleted CRD whose informers stop when timer fires
+type pendingCRDTeardown struct {
+	crd   *apiextensionsv1.CustomResourceDefinition
+	timer *time.Timer
+}
 
-// selectCRDVersion selects the most appropriate version for monitoring a CRD
-// Priority: 1) Storage version 2) Served version 3) First version
-func (c *Controller) selectCRDVersion(crd *apiextensionsv1.CustomResourceDefinition) (*apiextensionsv1.CustomResourceDefinitionVersion, error) {
-	if len(crd.Spec.Versions) == 0 {
-		return nil, fmt.Errorf("CRD %s has no versions", crd.Name)
-	}
-
-	// Strategy 1: Look for stored versions in status (most reliable)
-	if len(crd.Status.StoredVersions) > 0 {
-		// Find the first stored version that is also served
-		for _, storedVersion := range crd.Status.StoredVersions {
-			for _, specVersion := range crd.Spec.Versions {
-				if specVersion.Name == storedVersion && specVersion.Served {
-					c.logger.Debug("controller", fmt.Sprintf("Selected stored+served version %s for CRD %s", specVersion.Name, crd.Name))
-					return &specVersion, nil
-				}
-			}
-		}
-		c.logger.Debug("controller", fmt.Sprintf("No served stored versions found for CRD %s, falling back to storage flag", crd.Name))
+// cancelCRDTeardown cancels the pending informer teardown of a re-added CRD and reports whether
+// there was one. If the CRD came back with a different group, scope, plural or versions the
+// old informers are stopped at once instead, like a CRD update that requires a restart.
+func (c *Controller) cancelCRDTeardown(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	c.pendingCRDTeardownsMu.Lock()
+	pending, ok := c.pendingCRDTeardowns[crd.Name]
+	if ok {
+		pending.timer.Stop()
+		delete(c.pendingCRDTeardowns, crd.Name)
 	}
-
-	// Strategy 2: Look for version marked as storage=true and served=true in spec
-	for _, version := range crd.Spec.Versions {
-		if version.Storage && version.Served {
-			c.logger.Debug("controller", fmt.Sprintf("Selected storage+served version %s for CRD %s", version.Name, crd.Name))
-			return &version, nil
-		}
+	c.pendingCRDTeardownsMu.Unlock()
+	if !ok {
+		return false
 	}
-
-	// Strategy 3: Look for any served version
-	for _, version := range crd.Spec.Versions {
-		if version.Served {
-			c.logger.Debug("controller", fmt.Sprintf("Selected served version %s for CRD %s", version.Name, crd.Name))
-			return &version, nil
-		}
+	if c.crdUpdateRequiresRestart(pending.crd, crd) {
+		c.stopCRDInformer(pending.crd)
+		return false
 	}
-
-	// Strategy 4: Fall back to first version (should rarely happen)
-	c.logger.Warning("controller", fmt.Sprintf("No served versions found for CRD %s, using first version %s", crd.Name, crd.Spec.Versions[0].Name))
-	return &crd.Spec.Versions[0], nil
+	return true
 }
 
 
@@ -950,16 +2689,48 @@ func (c *Controller) createNamespaceSpecificInformer(config InformerConfig, name
 	// Simple selector application - complex interpretation removed
 	// Library users should implement their own selector logic via middleware
 	var tweakListOptions func(*metav1.ListOptions)
-	if len(normalizedConfigs) > 0 && normalizedConfigs[0].LabelSelector != "" {
-		labelSelector := normalizedConfigs[0].LabelSelector
+	labelSelector := config.LabelSelector
+	if labelSelector == "" && len(normalizedConfigs) > 0 {
+		labelSelector = normalizedConfigs[0].LabelSelector
+	}
+	fieldSelector := informerFieldSelector(normalizedConfigs)
+	pageSize := c.config.InformerListPageSize
+	fastStart := c.config.FastStart
+	useBookmarks := c.config.UseBookmarks
+	if labelSelector != "" || fieldSelector != "" || pageSize > 0 || fastStart || useBookmarks {
 		tweakListOptions = func(options *metav1.ListOptions) {
 			options.LabelSelector = labelSelector
+			options.FieldSelector = fieldSelector
+			// Chunk the initial list so large GVRs don't arrive in one response; ignored by watches
+			if pageSize > 0 {
+				options.Limit = pageSize
+			}
+			// Bookmarks advance the resourceVersion a dropped watch resumes from; the informer
+			// consumes them, so they never reach the event handlers
+			if useBookmarks && options.Watch {
+				options.AllowWatchBookmarks = true
+			}
+			// Serve every list from the API server's watch cache. Watches (the reflector always
+			// asks them for bookmarks) keep their resourceVersion, continuation pages must not set one
+			isWatch := options.Watch || options.AllowWatchBookmarks
+			if fastStart && !isWatch && options.Continue == "" {
+				options.ResourceVersion = "0"
+				options.ResourceVersionMatch = ""
+			}
 		}
 	}
 
-	// Create dynamic informer factory with namespace-specific filtering - pure event-driven, no resync needed
+	// CRITICAL FIX: Use namespace-specific key to avoid overwriting listers from other namespaces
+	listerKey := config.ListerKey
+	if listerKey == "" {
+		listerKey = config.GVRString + "@" + namespace
+	}
+
+	// Create dynamic informer factory with namespace-specific filtering - pure event-driven
+	// unless ResyncPeriodSec asks for periodic resyncs
+	resyncPeriod := c.informerResyncPeriod(listerKey)
 	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
-		c.client.Dynamic, 0, namespace, tweakListOptions)
+		c.client.DynamicClient(), resyncPeriod, namespace, tweakListOptions)
 	
 	// Get informer
 	informer := factory.ForResource(config.GVR).Informer()
@@ -967,32 +2738,150 @@ func (c *Controller) createNamespaceSpecificInformer(config InformerConfig, name
 		return nil, fmt.Errorf("failed to create namespace-specific informer for %s", config.GVRString)
 	}
 
+	// Trim objects before they reach the cache, so the lister only holds what the transform keeps
+	if err := informer.SetTransform(cacheTransform(c.objectTransform(config.GVRString))); err != nil {
+		return nil, fmt.Errorf("failed to set object transform for %s: %w", config.GVRString, err)
+	}
+
 	// Store the lister for later retrieval by workers
 	lister := factory.ForResource(config.GVR).Lister()
-	// CRITICAL FIX: Use namespace-specific key to avoid overwriting listers from other namespaces
-	listerKey := config.GVRString + "@" + namespace
 	c.listers.Store(listerKey, lister)
 
 	// Create state tracker
 	tracker := &InformerStateTracker{
 		GVR:    listerKey, // Use the same namespace-specific key
 		Lister: lister,
+		UIDCache: NewUIDCache(c.config.UIDCacheMaxEntries, func(key string) {
+			c.metrics.OnUIDCacheEviction(config.GVRString)
+			c.logger.Debug("controller", "Evicted UID cache entry: "+key)
+		}),
+		hasSynced:    informer.HasSynced,
+		resyncPeriod: resyncPeriod,
+	}
+	if c.config.DedupAcrossResync {
+		// A restarted informer inherits the versions seen by its predecessor
+		tracker.seenVersions = &sync.Map{}
+		if previous, exists := c.informerTrackers.Load(listerKey); exists && previous.(*InformerStateTracker).seenVersions != nil {
+			tracker.seenVersions = previous.(*InformerStateTracker).seenVersions
+		}
 	}
 	c.informerTrackers.Store(listerKey, tracker)
 	
 	// Notify metrics of informer creation
-	c.metrics.OnInformerCreated(config.GVRString, config.Scope)
+	c.metrics.OnInformerCreated(config.GVRString, config.Scope, informerConfigFormat(normalizedConfigs))
 	
 	// Hook into informer sync completion via callback
 	c.setupSyncCallback(informer, tracker, config)
 	
 	// Add state-tracking event handlers
-	informer.AddEventHandler(c.createStateTrackingEventHandlers(tracker, config))
+	registration, err := informer.AddEventHandler(c.createStateTrackingEventHandlers(tracker, config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add event handlers for %s: %w", config.GVRString, err)
+	}
+	if c.config.EmitSyncEvents {
+		go c.queueSyncEventWhenSynced(config, namespace, listerKey, normalizedConfigs, registration)
+	}
+	// With OR-ed LabelSelectors an object missing from one selector's list may be in another's
+	if store, ok := c.getUIDStore().(ListableUIDStore); ok && (len(normalizedConfigs) == 0 || len(normalizedConfigs[0].LabelSelectors) <= 1) {
+		go c.queueStoredDeletesWhenSynced(store, config, namespace, listerKey, normalizedConfigs, lister, registration)
+	}
 	
 	c.logger.Info("controller", fmt.Sprintf("Running namespace-specific informer for %s (namespace: %s)", config.GVRString, namespace))
 	return informer, nil
 }
 
+// informerConfigFormat reports the configuration format behind an informer's configs,
+// namespace-centric if any of them came from a "namespaces" entry
+func informerConfigFormat(configs []NormalizedConfig) string {
+	for _, config := range configs {
+		if config.ConfigFormat == ConfigFormatNamespace {
+			return ConfigFormatNamespace
+		}
+	}
+	return ConfigFormatResource
+}
+
+// queueStoredDeletesWhenSynced queues a DELETED work item, carrying the stored UID, for every
+// object in the UID store that is missing from the informer's synced initial list
+func (c *Controller) queueStoredDeletesWhenSynced(store ListableUIDStore, config InformerConfig, namespace, listerKey string, configs []NormalizedConfig, lister cache.GenericLister, registration cache.ResourceEventHandlerRegistration) {
+	if !cache.WaitForCacheSync(config.Context.Done(), registration.HasSynced) {
+		return
+	}
+	prefix := c.makeResourceKey(config.GVRString, namespace, "")
+	keys, err := store.Keys(prefix)
+	if err != nil {
+		c.logger.Warning("controller", fmt.Sprintf("Failed to list stored UIDs for %s: %v", config.GVRString, err))
+		return
+	}
+	for _, key := range keys {
+		// Stored keys are "gvr/" followed by the informer cache key ("namespace/name" or "name")
+		objectKey := strings.TrimPrefix(key, config.GVRString+"/")
+		if _, err := lister.Get(objectKey); err == nil {
+			continue
+		}
+		uid, found, err := store.Get(key)
+		if err != nil || !found {
+			continue
+		}
+		c.logger.Info("controller", fmt.Sprintf("Resource %s %s was deleted while not watched (UID: %s)", config.GVRString, objectKey, uid))
+		c.enqueueWorkItem(&WorkItem{
+			Key:        objectKey,
+			GVRString:  config.GVRString,
+			Configs:    configs,
+			EventType:  "DELETED",
+			ListerKey:  listerKey,
+			DeletedUID: uid,
+		})
+	}
+}
+
+// queueSyncEventWhenSynced queues a SYNCED work item once the handlers have received the
+// informer's whole initial list, so it is queued behind every initial ADDED event
+func (c *Controller) queueSyncEventWhenSynced(config InformerConfig, namespace, listerKey string, configs []NormalizedConfig, registration cache.ResourceEventHandlerRegistration) {
+	if !cache.WaitForCacheSync(config.Context.Done(), registration.HasSynced) {
+		return
+	}
+	c.enqueueWorkItem(&WorkItem{
+		Key:       namespace,
+		GVRString: config.GVRString,
+		Configs:   configs,
+		EventType: "SYNCED",
+		ListerKey: listerKey,
+	})
+}
+
+// processSyncEvent dispatches an informer's SYNCED checkpoint and exports it as a JSON event.
+// The event has no Object; Key holds the informer's namespace ("" for cluster-wide informers).
+func (c *Controller) processSyncEvent(workItem *WorkItem) {
+	namespace := workItem.Key
+	now := c.now()
+	c.logger.Info("controller", fmt.Sprintf("CONFIG [SYNCED] %s (namespace: %s)", workItem.GVRString, namespace))
+
+	event := MatchedEvent{
+		EventType: "SYNCED",
+		GVR:       workItem.GVRString,
+		Key:       namespace,
+		Timestamp: now,
+	}
+	event.Resource, event.Kind = c.discoveredResourceIdentity(workItem.GVRString)
+	if len(workItem.Configs) > 0 {
+		event.Config = workItem.Configs[0]
+	}
+	c.dispatchMatchedEvent(event)
+
+	jsonData, err := json.Marshal(JSONEvent{
+		Timestamp: c.formatJSONTime(now),
+		EventType: "SYNCED",
+		GVR:       workItem.GVRString,
+		Namespace: namespace,
+	})
+	if err != nil {
+		c.logger.Warning("controller", fmt.Sprintf("Failed to marshal JSON event: %v", err))
+		return
+	}
+	c.logger.Debug("controller", string(jsonData))
+}
+
 // InformerStartParams contains parameters for starting different types of informers
 type InformerStartParams struct {
 	GVR               schema.GroupVersionResource
@@ -1004,6 +2893,57 @@ type InformerStartParams struct {
 	NormalizedConfigs []NormalizedConfig // For CRD and namespace-specific informers (optional)
 	HandlerFunc       func(string, *unstructured.Unstructured) // Event handler function
 	Description       string // For logging
+	ListerKey         string // Lister/tracker key (default GVR@namespace)
+	LabelSelector     string // Overrides the configs' label selector (optional)
+}
+
+// informerFieldSelector combines the exact name and node field selectors of the configs
+// sharing an informer
+func informerFieldSelector(configs []NormalizedConfig) string {
+	var selectors []fields.Selector
+	for _, selector := range []string{exactNameFieldSelector(configs), nodeNameFieldSelector(configs)} {
+		if selector != "" {
+			selectors = append(selectors, fields.ParseSelectorOrDie(selector))
+		}
+	}
+	if len(selectors) == 0 {
+		return ""
+	}
+	return fields.AndSelectors(selectors...).String()
+}
+
+// nodeNameFieldSelector returns a spec.nodeName field selector when every config sharing the
+// informer selects Pods on the same node. Configs without a node need every Pod, so mixing
+// them returns "".
+func nodeNameFieldSelector(configs []NormalizedConfig) string {
+	if len(configs) == 0 || configs[0].NodeName == "" {
+		return ""
+	}
+	for _, config := range configs[1:] {
+		if config.NodeName != configs[0].NodeName {
+			return ""
+		}
+	}
+	return fields.OneTermEqualSelector("spec.nodeName", configs[0].NodeName).String()
+}
+
+// exactNameFieldSelector returns a metadata.name field selector when every config sharing
+// the informer selects the same literal name, so only that object is listed and watched.
+// Patterns (glob/regex characters) and mixed selectors return "" and list everything.
+func exactNameFieldSelector(configs []NormalizedConfig) string {
+	if len(configs) == 0 {
+		return ""
+	}
+	name := configs[0].NameSelector
+	if name == "" || strings.ContainsAny(name, "*?[]{}()^$|+\\") {
+		return ""
+	}
+	for _, config := range configs[1:] {
+		if config.NameSelector != name {
+			return ""
+		}
+	}
+	return fields.OneTermEqualSelector("metadata.name", name).String()
 }
 
 // startUnifiedInformer is a unified function that replaces startDynamicCRDInformer, startBuiltinInformer, and startNamespaceSpecificInformer
@@ -1015,16 +2955,28 @@ func (c *Controller) startUnifiedInformer(params InformerStartParams) {
 	if trackingKey == "" {
 		trackingKey = params.GVRString
 	}
+	exited := make(chan struct{})
+	c.informerExits.Store(trackingKey, exited)
+	defer close(exited)
+	defer c.informerExits.CompareAndDelete(trackingKey, exited)
 	defer c.activeInformers.Delete(trackingKey)
 
+	// Derived context so the circuit breaker and StopInformer can stop just this informer
+	informerCtx, cancelInformer := context.WithCancel(c.informerCtx)
+	defer cancelInformer()
+	c.cancellers.Store(trackingKey, cancelInformer)
+	defer c.cancellers.Delete(trackingKey)
+
 	// Create informer config
 	config := InformerConfig{
 		GVR:         params.GVR,
 		Scope:       params.Scope,
 		GVRString:   params.GVRString,
-		Context:     c.ctx,
+		Context:     informerCtx,
 		Name:        params.Name,
 		HandlerFunc: params.HandlerFunc,
+		ListerKey:     params.ListerKey,
+		LabelSelector: params.LabelSelector,
 	}
 	
 	// Create informer using appropriate factory
@@ -1036,78 +2988,212 @@ func (c *Controller) startUnifiedInformer(params InformerStartParams) {
 		c.logger.Error("controller", fmt.Sprintf("Failed to create %s: %v", params.Description, err))
 		return
 	}
+
+	if c.config.MaxInformerRestarts > 0 {
+		c.setupInformerCircuitBreaker(informer, params, cancelInformer)
+	}
 	
 	// Run with consistent logging
-	c.runInformerWithLogging(informer, c.ctx, params.Description)
+	c.runInformerWithLogging(informer, informerCtx, params.Description)
 }
 
-// stopCRDInformer stops the informer for a specific CRD
-func (c *Controller) stopCRDInformer(crd *apiextensionsv1.CustomResourceDefinition) {
-	c.logger.Info("controller", fmt.Sprintf("Stopping informer for deleted CRD: %s", crd.Name))
+// setupInformerCircuitBreaker counts list/watch failures of an informer and cancels it once
+// more than MaxInformerRestarts failures occur within the restart window, so a crash-looping
+// GVR cannot spin forever. The reflector's own backoff still applies between retries.
+func (c *Controller) setupInformerCircuitBreaker(informer cache.SharedIndexInformer, params InformerStartParams, cancel context.CancelFunc) {
+	window := time.Duration(c.config.InformerRestartWindowSec) * time.Second
+	if window <= 0 {
+		window = defaultInformerRestartWindow
+	}
 
-	// Declare variables for later use
-	var group, version, resource, gvrString string
+	// Only the reflector goroutine calls the handler, so failures needs no locking
+	var failures []time.Time
+	err := informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+
+		now := c.now()
+		recent := failures[:0]
+		for _, failure := range failures {
+			if now.Sub(failure) < window {
+				recent = append(recent, failure)
+			}
+		}
+		failures = append(recent, now)
+
+		if len(failures) <= c.config.MaxInformerRestarts {
+			c.logger.WarningWithFields("controller", "watch_error", fmt.Sprintf("Informer %s failed (%d/%d within %s): %v", params.Name, len(failures), c.config.MaxInformerRestarts, window, err), map[string]string{
+				"gvr": params.GVRString, "namespace": params.Namespace, "informer": params.Name, "error": err.Error(),
+			})
+			return
+		}
 
-	// Select version to build consistent GVR string
-	selectedVersion, err := c.selectCRDVersion(crd)
+		c.logger.ErrorWithFields("controller", "informer_circuit_open", fmt.Sprintf("Circuit breaker open for %s after %d failures within %s, stopping informer: %v", params.Name, len(failures), window, err), map[string]string{
+			"gvr": params.GVRString, "namespace": params.Namespace, "informer": params.Name, "error": err.Error(),
+		})
+		c.metrics.OnInformerCircuitOpen(params.GVRString)
+		cancel()
+
+		c.informerFailedMu.RLock()
+		callback := c.onInformerFailed
+		c.informerFailedMu.RUnlock()
+		if callback != nil {
+			go callback(params.GVRString, params.Namespace, err)
+		}
+	})
 	if err != nil {
-		c.logger.Warning("controller", fmt.Sprintf("Failed to select version for CRD %s during stop: %v", crd.Name, err))
-		return
+		c.logger.Warning("controller", fmt.Sprintf("Failed to install circuit breaker for %s: %v", params.Name, err))
 	}
+}
 
-	// Convert CRD to GVR string for consistent key lookup
-	gvrString = fmt.Sprintf("%s/%s/%s", crd.Spec.Group, selectedVersion.Name, crd.Spec.Names.Plural)
+// stopCRDInformer stops the informers of every version of a CRD and waits for them to exit,
+// so a CRD that is updated or re-added can have its informers started again right away
+func (c *Controller) stopCRDInformer(crd *apiextensionsv1.CustomResourceDefinition) {
+	c.logger.Info("controller", fmt.Sprintf("Stopping informer for deleted CRD: %s", crd.Name))
 
-	// Get cancel function and stop the informer gracefully using GVR string
-	if cancelFunc, exists := c.cancellers.LoadAndDelete(gvrString); exists {
-		if cancel, ok := cancelFunc.(context.CancelFunc); ok {
-			c.logger.Debug("controller", fmt.Sprintf("Cancelling context for CRD %s (GVR: %s)", crd.Name, gvrString))
-			cancel()
-			c.logger.Info("controller", fmt.Sprintf("Gracefully stopped dynamic informer for CRD %s", crd.Name))
-		} else {
-			c.logger.Warning("controller", fmt.Sprintf("Invalid cancel function type for CRD %s", crd.Name))
+	// Declare variables for later use
+	var group, version, resource, gvrString string
+
+	// Informers are keyed by informer key ("gvr@namespace[|selector]"); config entries may
+	// target any served version, so match the informers of all of them
+	var keys []string
+	c.cancellers.Range(func(key, value interface{}) bool {
+		for _, crdVersion := range crd.Spec.Versions {
+			prefix := fmt.Sprintf("%s/%s/%s@", crd.Spec.Group, crdVersion.Name, crd.Spec.Names.Plural)
+			if strings.HasPrefix(key.(string), prefix) {
+				keys = append(keys, key.(string))
+				break
+			}
 		}
+		return true
+	})
+	if stopped := c.stopInformers(keys); stopped > 0 {
+		c.logger.Info("controller", fmt.Sprintf("Gracefully stopped %d informers for CRD %s", stopped, crd.Name))
 	} else {
 		c.logger.Debug("controller", fmt.Sprintf("No active informer found for CRD %s (may not have matched configuration)", crd.Name))
 	}
 
-	// Remove from discovered resources - handle potential edge cases
+	// Remove every version from discovered resources - handle potential edge cases
 	if len(crd.Spec.Versions) == 0 {
 		c.logger.Warning("controller", fmt.Sprintf("CRD %s has no versions, cannot clean up discovered resources", crd.Name))
 		return
 	}
 
 	group = crd.Spec.Group
-	version = crd.Spec.Versions[0].Name
 	resource = crd.Spec.Names.Plural
-	gvrString = fmt.Sprintf("%s/%s/%s", group, version, resource)
 
 	c.discoveredResourcesMu.Lock()
-	if _, exists := c.discoveredResources[gvrString]; exists {
-		delete(c.discoveredResources, gvrString)
-		c.logger.Debug("controller", fmt.Sprintf("Removed %s from discovered resources", gvrString))
-	} else {
-		c.logger.Debug("controller", fmt.Sprintf("Resource %s not found in discovered resources (already cleaned up)", gvrString))
+	for _, crdVersion := range crd.Spec.Versions {
+		version = crdVersion.Name
+		gvrString = fmt.Sprintf("%s/%s/%s", group, version, resource)
+		if c.removeDiscoveredResource(gvrString) {
+			c.logger.Debug("controller", fmt.Sprintf("Removed %s from discovered resources", gvrString))
+		} else {
+			c.logger.Debug("controller", fmt.Sprintf("Resource %s not found in discovered resources (already cleaned up)", gvrString))
+		}
 	}
 	c.discoveredResourcesMu.Unlock()
+	c.updateDiscoveryMetrics()
+}
+
+// informerStopTimeout bounds how long stopInformers waits for cancelled informers to exit
+const informerStopTimeout = 30 * time.Second
+
+// stopInformers cancels the informers with the given keys and waits until they have exited
+// and released their keys, so the same informers can be started again at once. It returns
+// how many were cancelled, and gives up waiting after informerStopTimeout.
+func (c *Controller) stopInformers(keys []string) int {
+	var exits []chan struct{}
+	for _, key := range keys {
+		cancel, exists := c.cancellers.Load(key)
+		if !exists {
+			continue
+		}
+		if exited, ok := c.informerExits.Load(key); ok {
+			exits = append(exits, exited.(chan struct{}))
+		}
+		c.logger.Debug("controller", fmt.Sprintf("Cancelling context for informer %s", key))
+		cancel.(context.CancelFunc)()
+	}
+
+	deadline := time.NewTimer(informerStopTimeout)
+	defer deadline.Stop()
+	for _, exited := range exits {
+		select {
+		case <-exited:
+		case <-deadline.C:
+			c.logger.Warning("controller", fmt.Sprintf("Timed out after %s waiting for stopped informers to exit", informerStopTimeout))
+			return len(exits)
+		}
+	}
+	return len(exits)
 }
 
-// startConfigDrivenInformers starts informers based on config and discovery results
-func (c *Controller) startConfigDrivenInformers() error {
+// checkConfiguredScope compares the scope declared in the configs with the discovered one.
+// Discovery always wins; a mismatch is logged, or returned with FailOnScopeMismatch.
+func (c *Controller) checkConfiguredScope(gvrString string, namespaced bool, configs []NormalizedConfig) error {
+	discovered := ClusterScope
+	if namespaced {
+		discovered = NamespaceScope
+	}
+	for _, config := range configs {
+		if config.Scope == "" || config.Scope == discovered {
+			continue
+		}
+		message := fmt.Sprintf("Resource %s is configured with scope %s but discovery reports %s", gvrString, config.Scope, discovered)
+		if c.config.FailOnScopeMismatch {
+			return errors.New(message)
+		}
+		c.logger.Warning("controller", message+", using discovered scope")
+		return nil
+	}
+	return nil
+}
+
+// startConfigDrivenInformers starts informers based on config and discovery results. It returns
+// the lister keys of the informers it started and the NamespaceLabelSelector watchers it
+// started; informers and watchers already running are skipped. waitForPriorities applies
+// PriorityWaitForSync, which only Start does: the other callers run in event handlers and
+// background loops that must not stall for PrioritySyncTimeoutSec.
+func (c *Controller) startConfigDrivenInformers(waitForPriorities bool) ([]string, []*namespaceWatcher, error) {
 	c.logger.Info("controller", "Starting config-driven informers for resources")
 
 	// Normalize configuration to unified internal structure
-	normalizedGVRs, err := c.config.Normalize()
+	normalizedGVRs, err := c.normalizeConfig()
 	if err != nil {
-		return fmt.Errorf("failed to normalize configuration: %w", err)
+		return nil, nil, fmt.Errorf("failed to normalize configuration: %w", err)
 	}
 
 	c.logger.Info("controller", fmt.Sprintf("Normalized configuration: monitoring %d unique GVRs", len(normalizedGVRs)))
 
-	informerCount := 0
+	var startedKeys []string
+	var startedWatchers []*namespaceWatcher
+
+	// Start GVRs in priority order (lowest first), by name within a priority
+	gvrStrings := make([]string, 0, len(normalizedGVRs))
+	for gvrString := range normalizedGVRs {
+		gvrStrings = append(gvrStrings, gvrString)
+	}
+	sort.Slice(gvrStrings, func(i, j int) bool {
+		pi, pj := configsPriority(normalizedGVRs[gvrStrings[i]]), configsPriority(normalizedGVRs[gvrStrings[j]])
+		if pi != pj {
+			return pi < pj
+		}
+		return gvrStrings[i] < gvrStrings[j]
+	})
+
+	// Informers started for the current priority, waited on before the next one with PriorityWaitForSync
+	var levelKeys []string
+	for i, gvrString := range gvrStrings {
+		normalizedConfigs := normalizedGVRs[gvrString]
+		priority := configsPriority(normalizedConfigs)
+		if waitForPriorities && i > 0 && priority != configsPriority(normalizedGVRs[gvrStrings[i-1]]) && len(levelKeys) > 0 {
+			c.logger.Info("controller", fmt.Sprintf("Waiting for %d informer(s) below priority %d to sync", len(levelKeys), priority))
+			if err := c.waitForPrioritySynced(levelKeys); err != nil {
+				return nil, nil, err
+			}
+			levelKeys = nil
+		}
 
-	// Start separate informers per namespace+GVR combination
-	for gvrString, normalizedConfigs := range normalizedGVRs {
 		c.logger.Info("controller", fmt.Sprintf("Processing %s (matches %d configuration entries)", gvrString, len(normalizedConfigs)))
 
 		// Look up resource info from discovery
@@ -1115,7 +3201,15 @@ func (c *Controller) startConfigDrivenInformers() error {
 		resourceInfo, found := c.discoveredResources[gvrString]
 		c.discoveredResourcesMu.RUnlock()
 		if !found {
-			c.logger.Warning("controller", fmt.Sprintf("Resource %s not found in discovery results, skipping", gvrString))
+			if reason := c.unavailableReason(gvrString); reason != nil {
+				c.logger.Warning("controller", fmt.Sprintf("Resource %s is degraded, skipping until its API group version is available again: %v", gvrString, reason))
+			} else {
+				c.logger.Warning("controller", fmt.Sprintf("Resource %s not found in discovery results, skipping", gvrString))
+			}
+			continue
+		}
+		if !resourceInfo.Watchable {
+			c.logger.Warning("controller", fmt.Sprintf("Resource %s does not support both list and watch verbs and cannot be monitored, skipping", gvrString))
 			continue
 		}
 
@@ -1132,13 +3226,20 @@ func (c *Controller) startConfigDrivenInformers() error {
 		} else {
 			scope = apiextensionsv1.ClusterScoped
 		}
+		if err := c.checkConfiguredScope(gvrString, resourceInfo.Namespaced, normalizedConfigs); err != nil {
+			return nil, nil, err
+		}
 
 		// Group configs by namespace to create separate informers
 		namespaceGroups := make(map[string][]NormalizedConfig)
+		selectedGroups := make(map[string][]NormalizedConfig) // NamespaceLabelSelector -> configs
 		for _, config := range normalizedConfigs {
 			if scope == apiextensionsv1.ClusterScoped {
 				// For cluster-scoped resources, ignore NamespaceNames and use cluster-scoped grouping
 				namespaceGroups["cluster-scoped"] = append(namespaceGroups["cluster-scoped"], config)
+			} else if config.NamespaceLabelSelector != "" {
+				// Namespaces selected by label get their informers from a namespace watcher
+				selectedGroups[config.NamespaceLabelSelector] = append(selectedGroups[config.NamespaceLabelSelector], config)
 			} else {
 				// For namespace-scoped resources, group by specified namespaces
 				for _, ns := range config.NamespaceNames {
@@ -1150,41 +3251,193 @@ func (c *Controller) startConfigDrivenInformers() error {
 			}
 		}
 
-		// Create separate informer for each namespace
-		for namespace, configs := range namespaceGroups {
-			informerKey := gvrString + "@" + namespace
-			
-			// Mark this GVR+namespace as having an active informer
-			c.activeInformers.Store(informerKey, true)
-			
-			actualNamespace := namespace
-			if namespace == "cluster-scoped" {
-				actualNamespace = ""
-			}
-			
-			c.logger.Info("controller", fmt.Sprintf("Setting up informer for %s (namespace: %s)", gvrString, actualNamespace))
-			
-			// Start separate informer for this namespace+GVR combination
-		c.wg.Add(1)
-			go c.startUnifiedInformer(InformerStartParams{
-				GVR:               gvr,
-				Scope:             scope,
-				GVRString:         gvrString,
-				Name:              informerKey,
-				InformerKey:       informerKey,
-				Namespace:         actualNamespace,
-				NormalizedConfigs: configs,
-		HandlerFunc: func(eventType string, obj *unstructured.Unstructured) {
-					c.handleNamespaceSpecificEvent(eventType, obj, gvrString, configs)
-				},
-				Description:       fmt.Sprintf("namespace-specific informer for %s (namespace: %s)", gvrString, actualNamespace),
-			})
-		informerCount++
+		// Create separate informer for each namespace
+		for namespace, configs := range namespaceGroups {
+			listerKeys := c.startNamespaceInformers(gvr, scope, gvrString, namespace, configs)
+			startedKeys = append(startedKeys, listerKeys...)
+			levelKeys = append(levelKeys, listerKeys...)
+		}
+		for selector, configs := range selectedGroups {
+			if watcher := c.startNamespaceSelectorWatcher(gvr, scope, gvrString, selector, configs); watcher != nil {
+				startedWatchers = append(startedWatchers, watcher)
+			}
+		}
+	}
+
+	c.logger.Info("controller", fmt.Sprintf("Started %d config-driven informers", len(startedKeys)))
+	return startedKeys, startedWatchers, nil
+}
+
+// startNamespaceInformers starts the informers of one GVR in one namespace ("cluster-scoped"
+// for cluster scope) and returns the lister keys of the ones started
+func (c *Controller) startNamespaceInformers(gvr schema.GroupVersionResource, scope apiextensionsv1.ResourceScope, gvrString, namespace string, configs []NormalizedConfig) []string {
+	actualNamespace := namespace
+	if namespace == "cluster-scoped" {
+		actualNamespace = ""
+	}
+
+	// OR-ed LabelSelectors get one informer each; the first keeps the plain
+	// GVR@namespace keys, the others are suffixed with "|<selector>"
+	selectors := []string{""}
+	if len(configs[0].LabelSelectors) > 0 {
+		selectors = configs[0].LabelSelectors
+	}
+
+	var started []string
+	for i, selector := range selectors {
+		suffix := ""
+		if i > 0 {
+			suffix = "|" + selector
+		}
+		informerKey := gvrString + "@" + namespace + suffix
+		listerKey := gvrString + "@" + actualNamespace + suffix // "gvr@" for cluster scope
+
+		// Mark this GVR+namespace as having an active informer, skipping ones already running
+		// (StartInformers re-runs this for the whole config after AddResources)
+		if _, alreadyActive := c.activeInformers.LoadOrStore(informerKey, listerKey); alreadyActive {
+			c.logger.Debug("controller", fmt.Sprintf("Informer for %s already active, skipping", informerKey))
+			continue
+		}
+
+		c.logger.Info("controller", fmt.Sprintf("Setting up informer for %s (namespace: %s)", gvrString, actualNamespace))
+
+		// Start separate informer for this namespace+GVR(+selector) combination
+		c.wg.Add(1)
+		go c.startUnifiedInformer(InformerStartParams{
+			GVR:               gvr,
+			Scope:             scope,
+			GVRString:         gvrString,
+			Name:              informerKey,
+			InformerKey:       informerKey,
+			Namespace:         actualNamespace,
+			NormalizedConfigs: configs,
+			HandlerFunc: func(eventType string, obj *unstructured.Unstructured) {
+				c.handleNamespaceSpecificEvent(eventType, obj, gvrString, configs, listerKey)
+			},
+			Description:   fmt.Sprintf("namespace-specific informer for %s (namespace: %s)", gvrString, actualNamespace),
+			ListerKey:     listerKey,
+			LabelSelector: selector,
+		})
+		started = append(started, listerKey)
+	}
+	return started
+}
+
+// namespaceWatcher is a running NamespaceLabelSelector watcher of one GVR
+type namespaceWatcher struct {
+	selector string
+	synced   chan struct{} // Closed once the initial namespace list was handled
+
+	// Informer keys the watcher started, by namespace (lister and informer keys are the same
+	// for a named namespace). Only these are stopped, never informers other configs started.
+	mu      sync.Mutex
+	started map[string][]string
+}
+
+// listerKeys returns the lister keys of the informers the watcher is running
+func (w *namespaceWatcher) listerKeys() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var keys []string
+	for _, namespaceKeys := range w.started {
+		keys = append(keys, namespaceKeys...)
+	}
+	return keys
+}
+
+// startNamespaceSelectorWatcher watches the namespaces matching a NamespaceLabelSelector and
+// starts the GVR's informers in each of them, stopping them again when a namespace is deleted
+// or stops matching. Labels are also checked client-side, since not every watch filters them.
+// Returns nil when the watcher is already running or could not be started.
+func (c *Controller) startNamespaceSelectorWatcher(gvr schema.GroupVersionResource, scope apiextensionsv1.ResourceScope, gvrString, selector string, configs []NormalizedConfig) *namespaceWatcher {
+	// StartInformers re-runs startConfigDrivenInformers, keep one watcher per GVR and selector
+	watcherKey := gvrString + "|" + selector
+	watcher := &namespaceWatcher{selector: selector, synced: make(chan struct{}), started: make(map[string][]string)}
+	if _, running := c.namespaceWatchers.LoadOrStore(watcherKey, watcher); running {
+		return nil
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		c.namespaceWatchers.Delete(watcherKey)
+		c.logger.Error("controller", fmt.Sprintf("Invalid namespace label selector %q for %s: %v", selector, gvrString, err))
+		return nil
+	}
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+		c.client.DynamicClient(), 0, "", func(options *metav1.ListOptions) {
+			options.LabelSelector = selector
+		})
+	namespaceInformer := factory.ForResource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}).Informer()
+
+	stop := func(namespace, reason string) {
+		watcher.mu.Lock()
+		keys := watcher.started[namespace]
+		delete(watcher.started, namespace)
+		watcher.mu.Unlock()
+		// Wait for them to exit, so a namespace that matches again right away can restart them
+		if c.stopInformers(keys) > 0 {
+			c.logger.Info("controller", fmt.Sprintf("Namespace %s %s, stopped informers for %s", namespace, reason, gvrString))
+		}
+	}
+	reconcileNamespace := func(obj interface{}) {
+		namespace, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			c.logger.Error("controller", fmt.Sprintf("Received unexpected object type %T in namespace watcher for %s", obj, gvrString))
+			return
+		}
+		if parsed.Matches(labels.Set(namespace.GetLabels())) {
+			keys := c.startNamespaceInformers(gvr, scope, gvrString, namespace.GetName(), configs)
+			watcher.mu.Lock()
+			watcher.started[namespace.GetName()] = append(watcher.started[namespace.GetName()], keys...)
+			watcher.mu.Unlock()
+		} else {
+			stop(namespace.GetName(), fmt.Sprintf("no longer matches %q", selector))
+		}
+	}
+	registration, err := namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: reconcileNamespace,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			reconcileNamespace(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown); isTombstone {
+				obj = tombstone.Obj
+			}
+			if namespace, ok := obj.(*unstructured.Unstructured); ok {
+				stop(namespace.GetName(), "was deleted")
+			}
+		},
+	})
+	if err != nil {
+		c.namespaceWatchers.Delete(watcherKey)
+		c.logger.Error("controller", fmt.Sprintf("Failed to watch namespaces matching %q for %s: %v", selector, gvrString, err))
+		return nil
+	}
+
+	c.logger.Info("controller", fmt.Sprintf("Watching namespaces matching %q for %s", selector, gvrString))
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer c.namespaceWatchers.Delete(watcherKey)
+		namespaceInformer.Run(c.informerCtx.Done())
+	}()
+	go func() {
+		if cache.WaitForCacheSync(c.informerCtx.Done(), registration.HasSynced) {
+			close(watcher.synced)
+		}
+	}()
+	return watcher
+}
+
+// configsPriority returns the start priority of a GVR: the lowest priority of its configs
+func configsPriority(configs []NormalizedConfig) int {
+	priority := 0
+	for i, config := range configs {
+		if i == 0 || config.Priority < priority {
+			priority = config.Priority
 		}
 	}
-
-	c.logger.Info("controller", fmt.Sprintf("Started %d config-driven informers", informerCount))
-	return nil
+	return priority
 }
 
 
@@ -1208,7 +3461,75 @@ func (c *Controller) handleCustomResourceEvent(eventType string, obj *unstructur
 	// Future: Add sophisticated event processing, correlation, file output, etc.
 }
 
-// GetActiveInformers returns the count of active informers
+// GetDiscoveredResources returns a copy of the API resources found during discovery, keyed by GVR string
+func (c *Controller) GetDiscoveredResources() map[string]ResourceInfo {
+	c.discoveredResourcesMu.RLock()
+	defer c.discoveredResourcesMu.RUnlock()
+
+	resources := make(map[string]ResourceInfo, len(c.discoveredResources))
+	for gvrString, info := range c.discoveredResources {
+		resources[gvrString] = *info
+	}
+	return resources
+}
+
+// InvolvedObjectGVR resolves the object a core (v1/events) or events.k8s.io event refers to
+// (involvedObject or regarding) to its GVR string, namespace and name. The plural resource
+// name comes from API discovery, so it is only resolved after Start and ok is false for
+// kinds the API server doesn't serve.
+func (c *Controller) InvolvedObjectGVR(event *unstructured.Unstructured) (gvr, namespace, name string, ok bool) {
+	if event == nil {
+		return "", "", "", false
+	}
+	ref, found, err := unstructured.NestedStringMap(event.Object, "involvedObject")
+	if !found || err != nil {
+		ref, found, err = unstructured.NestedStringMap(event.Object, "regarding")
+	}
+	if !found || err != nil {
+		return "", "", "", false
+	}
+
+	groupVersion, err := schema.ParseGroupVersion(ref["apiVersion"])
+	if err != nil || ref["kind"] == "" {
+		return "", "", "", false
+	}
+
+	gvr, _, ok = c.resourceForKind(groupVersion.WithKind(ref["kind"]))
+	if !ok {
+		return "", "", "", false
+	}
+	return gvr, ref["namespace"], ref["name"], true
+}
+
+// StopInformer stops the informers watching gvrString in namespace ("" for cluster-scoped
+// resources), including those of additional OR-ed label selectors. Objects they cached stay
+// readable through GetObject and queued events are still delivered; StartInformers starts
+// them again. It returns an error when no such informer is running.
+func (c *Controller) StopInformer(gvrString, namespace string) error {
+	listerKey := gvrString + "@" + namespace
+	var stopped []string
+	c.activeInformers.Range(func(key, value interface{}) bool {
+		if activeKey, _ := value.(string); activeKey != listerKey && !strings.HasPrefix(activeKey, listerKey+"|") {
+			return true
+		}
+		if cancel, exists := c.cancellers.Load(key); exists {
+			cancel.(context.CancelFunc)()
+			stopped = append(stopped, key.(string))
+		}
+		return true
+	})
+	if len(stopped) == 0 {
+		return fmt.Errorf("no running informer for %s in namespace %q", gvrString, namespace)
+	}
+
+	sort.Strings(stopped)
+	c.logger.Info("controller", fmt.Sprintf("Stopped informers on request: %s", strings.Join(stopped, ", ")))
+	return nil
+}
+
+// GetActiveInformers returns the count of active informers: config counts every informer
+// registered for the configuration (including ones still starting), dynamic the informers
+// currently running, each of which can be stopped individually
 func (c *Controller) GetActiveInformers() (config int, dynamic int) {
 	// Count config-driven informers
 	config = 0
@@ -1217,7 +3538,7 @@ func (c *Controller) GetActiveInformers() (config int, dynamic int) {
 		return true
 	})
 
-	// Count dynamic informers
+	// Count running informers by their cancel functions
 	dynamic = 0
 	c.cancellers.Range(func(key, value interface{}) bool {
 		dynamic++
@@ -1228,14 +3549,87 @@ func (c *Controller) GetActiveInformers() (config int, dynamic int) {
 	return config, dynamic
 }
 
-// Stop gracefully shuts down all informers with timeout
+// DescribeInformers returns the status of every running informer, ordered by GVR,
+// namespace and label selector. Informers stopped by the circuit breaker are not included.
+func (c *Controller) DescribeInformers() []InformerStatus {
+	var statuses []InformerStatus
+	c.activeInformers.Range(func(key, value interface{}) bool {
+		listerKey, _ := value.(string)
+		gvrString, namespace, _ := strings.Cut(listerKey, "@")
+		namespace, selector, _ := strings.Cut(namespace, "|")
+		status := InformerStatus{
+			GVR:           gvrString,
+			Namespace:     namespace,
+			LabelSelector: selector,
+			Health:        InformerHealthSyncing,
+		}
+
+		// Informers are started asynchronously - one without a tracker is still starting
+		if trackerInterface, exists := c.informerTrackers.Load(listerKey); exists {
+			tracker := trackerInterface.(*InformerStateTracker)
+			status.Synced = tracker.hasSynced()
+			if status.Synced {
+				status.Health = InformerHealthHealthy
+			}
+			if objects, err := tracker.Lister.List(labels.Everything()); err == nil {
+				status.TrackedCount = len(objects)
+			}
+			if lastEvent := tracker.lastEvent.Load(); lastEvent > 0 {
+				lastEventTime := time.Unix(0, lastEvent)
+				status.LastEventTime = &lastEventTime
+			}
+			status.ResyncPeriod = tracker.resyncPeriod
+		}
+		statuses = append(statuses, status)
+		return true
+	})
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].GVR != statuses[j].GVR {
+			return statuses[i].GVR < statuses[j].GVR
+		}
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].LabelSelector < statuses[j].LabelSelector
+	})
+	return statuses
+}
+
+// Stop gracefully shuts down all informers with timeout.
+// Stopping a controller that was never started, or stopping it twice, is a no-op.
 func (c *Controller) Stop() {
+	if !c.state.CompareAndSwap(controllerStateStarted, controllerStateStopped) {
+		c.logger.Debug("controller", "Stop called on a controller that is not running, ignoring")
+		return
+	}
+
 	c.logger.Info("controller", "Stopping multi-layered informer controller")
 
-	// Cancel main context - this stops all informers
-	c.cancel()
+	// Let embedding apps flush state while informers and caches are still live
+	c.shutdownMu.Lock()
+	callbacks := c.onShutdown
+	c.shutdownMu.Unlock()
+	for _, callback := range callbacks {
+		if callback != nil {
+			callback()
+		}
+	}
+
+	// Stop all informers; handlers and API calls keep the main context until the queued events
+	// are delivered
+	c.cancelInformers()
+
+	// Queue the events still waiting in a dedup window
+	if c.debouncer != nil {
+		c.debouncer.Stop()
+	}
 
-	// Shutdown the work queue to stop workers
+	// Failed items waiting out their retry backoff would be lost with the delayed queue, give
+	// them one last attempt instead
+	c.requeueBackoffItems()
+
+	// Shutdown the work queue to stop workers once they have drained it
 	c.workQueue.ShutDown()
 
 	// Stop all dynamic informers explicitly
@@ -1253,10 +3647,63 @@ func (c *Controller) Stop() {
 		c.logger.Info("controller", fmt.Sprintf("Cancelled %d dynamic informers", dynamicCount))
 	}
 
-	// Wait for all goroutines to finish gracefully - no arbitrary timeout
+	// Wait for all goroutines to finish gracefully, bounded by StopTimeoutSec when set
 	c.logger.Info("controller", "Waiting for all informers and workers to stop gracefully...")
+	done := make(chan struct{})
+	go func() {
 		c.wg.Wait()
+		close(done)
+	}()
+
+	var timeout <-chan time.Time
+	if c.config.StopTimeoutSec > 0 {
+		timeout = time.After(time.Duration(c.config.StopTimeoutSec) * time.Second)
+	}
+	select {
+	case <-done:
 		c.logger.Info("controller", "All informers and workers stopped gracefully")
+	case <-timeout:
+		// Goroutines can't be killed - report what is stuck and return so the process can exit
+		var running []string
+		c.activeInformers.Range(func(key, value interface{}) bool {
+			running = append(running, fmt.Sprint(key))
+			return true
+		})
+		sort.Strings(running)
+		if len(running) > 0 {
+			c.logger.Warning("controller", fmt.Sprintf("Stop timed out after %ds, informers still running: %s", c.config.StopTimeoutSec, strings.Join(running, ", ")))
+		} else {
+			c.logger.Warning("controller", fmt.Sprintf("Stop timed out after %ds, workers still busy processing events", c.config.StopTimeoutSec))
+		}
+	}
+	if c.workersDrained != nil {
+		close(c.workersDrained)
+	}
+	
+	// Deliver events still pending in handler queues and batches
+	c.handlersMu.RLock()
+	var queues []*deliveryQueue
+	for _, registered := range c.eventHandlers {
+		queues = append(queues, registered.queue)
+	}
+	for _, scoped := range c.gvrEventHandlers {
+		for _, registered := range scoped {
+			queues = append(queues, registered.queue)
+		}
+	}
+	batchers := c.batchers
+	c.handlersMu.RUnlock()
+	for _, queue := range queues {
+		if queue != nil {
+			queue.Stop()
+		}
+	}
+	for _, batcher := range batchers {
+		batcher.Stop()
+	}
+
+	// Everything queued was delivered, cancel what is still running past StopTimeoutSec
+	c.cancel()
 	
 	// Shutdown metrics server gracefully without timeout
 	if c.metrics != nil {
@@ -1285,25 +3732,46 @@ func (c *Controller) processNextWorkItem() bool {
 	// Always call Done to mark this item as processed
 	defer c.workQueue.Done(obj)
 
-	var workItem *WorkItem
-	var ok bool
-	if workItem, ok = obj.(*WorkItem); !ok {
+	key, ok := obj.(string)
+	if !ok {
 		// Invalid item, forget it
 		c.workQueue.Forget(obj)
-		c.logger.Warning("controller", fmt.Sprintf("Expected WorkItem but got %T", obj))
+		c.logger.Warning("controller", fmt.Sprintf("Expected work queue key but got %T", obj))
 		return true
 	}
 
-	// Process the work item
-	if err := c.reconcile(workItem); err != nil {
-		// Re-queue the item on failure with exponential backoff
-		c.workQueue.AddRateLimited(workItem)
-		c.logger.Error("controller", fmt.Sprintf("Error processing %s: %v", workItem.Key, err))
-		return true
+	// Process the object's events in arrival order - until Done, the workqueue won't hand
+	// this key to another worker, so events for one object are never processed concurrently
+	items := c.takePendingItems(key)
+	defer c.finishPendingItems(len(items))
+	for i, workItem := range items {
+		if err := c.reconcile(workItem); err != nil {
+			// Give up on an item that keeps failing, so it can't occupy a worker forever
+			if maxRetries := c.config.MaxReconcileRetries; maxRetries > 0 && c.workQueue.NumRequeues(key) >= maxRetries {
+				c.workQueue.Forget(key)
+				c.metrics.OnWorkItemDropped(workItem.GVRString, workItem.EventType)
+				c.logger.ErrorWithFields("controller", "work_item_dropped", fmt.Sprintf("Dropping %s event for %s %s after %d retries: %v", workItem.EventType, workItem.GVRString, workItem.Key, maxRetries, err), map[string]string{
+					"gvr": workItem.GVRString, "key": workItem.Key, "event_type": workItem.EventType, "error": err.Error(),
+				})
+				if remaining := items[i+1:]; len(remaining) > 0 {
+					c.requeuePendingItems(key, remaining)
+					c.workQueue.Add(key)
+				}
+				return true
+			}
+			
+			// Re-queue the failed and remaining items with exponential backoff
+			c.requeuePendingItems(key, items[i:])
+			c.workQueue.AddRateLimited(key)
+			c.logger.ErrorWithFields("controller", "reconcile_failed", fmt.Sprintf("Error processing %s: %v", workItem.Key, err), map[string]string{
+				"gvr": workItem.GVRString, "key": workItem.Key, "event_type": workItem.EventType, "error": err.Error(),
+			})
+			return true
+		}
 	}
 
-	// Successfully processed, forget the item
-	c.workQueue.Forget(workItem)
+	// Successfully processed, forget the key
+	c.workQueue.Forget(key)
 	return true
 }
 
@@ -1314,6 +3782,12 @@ func (c *Controller) reconcile(workItem *WorkItem) error {
 
 	// At this point, we know the object is one we are configured to watch.
 
+	// Sync checkpoints don't refer to an object
+	if workItem.EventType == "SYNCED" {
+		c.processSyncEvent(workItem)
+		return nil
+	}
+
 	// Get lister for this GVR - namespace-specific key only, no fallbacks
 	namespace, _, keyErr := cache.SplitMetaNamespaceKey(workItem.Key)
 	if keyErr != nil {
@@ -1321,7 +3795,10 @@ func (c *Controller) reconcile(workItem *WorkItem) error {
 		return errors.New("failed to parse workItem key: " + workItem.Key)
 	}
 	
-	namespaceListerKey := workItem.GVRString + "@" + namespace
+	namespaceListerKey := workItem.ListerKey
+	if namespaceListerKey == "" {
+		namespaceListerKey = workItem.GVRString + "@" + namespace
+	}
 	listerInterface, exists := c.listers.Load(namespaceListerKey)
 	if !exists {
 		c.logger.Error("controller", "No lister found for key: "+namespaceListerKey)
@@ -1334,18 +3811,26 @@ func (c *Controller) reconcile(workItem *WorkItem) error {
 		return errors.New("invalid lister type for GVR " + workItem.GVRString)
 	}
 
-	obj, err := lister.Get(workItem.Key)
+	// UID fallback keys don't name the object in the lister - use the object from the event
+	var obj runtime.Object
+	var err error
+	deletion := workItem.EventType == "DELETED" || workItem.EventType == EventTypeEphemeral
+	if workItem.Object != nil && !deletion {
+		obj = workItem.Object
+	} else {
+		obj, err = lister.Get(workItem.Key)
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			// Only process as DELETED if the workItem.EventType is actually DELETED
-			if workItem.EventType != "DELETED" {
+			// Only process as DELETED if the workItem.EventType is actually DELETED (or EPHEMERAL)
+			if !deletion {
 				// Object was deleted after ADDED/UPDATED event was queued - skip processing
 				c.logger.Debug("controller", fmt.Sprintf("Skipping %s event for %s %s - object no longer exists", workItem.EventType, workItem.GVRString, workItem.Key))
 				return nil
 			}
 			
 			// The object was deleted. Log CONFIG message and call OnMatched handlers.
-			c.logger.Info("controller", fmt.Sprintf("CONFIG [DELETED] %s %s", workItem.GVRString, workItem.Key))
+			c.logger.Info("controller", fmt.Sprintf("CONFIG [%s] %s %s", workItem.EventType, workItem.GVRString, workItem.Key))
 			
 			// Parse the key to get namespace and name for JSON event
 			namespace, name, keyErr := cache.SplitMetaNamespaceKey(workItem.Key)
@@ -1354,14 +3839,20 @@ func (c *Controller) reconcile(workItem *WorkItem) error {
 				name = workItem.Key
 				namespace = ""
 			}
-			
-			// Use captured UID and annotations from WorkItem for DELETED events - no fallbacks
-			if workItem.DeletedUID == "" {
-				c.logger.Error("controller", "No captured UID for DELETED event: "+workItem.Key)
-				return errors.New("no captured UID for DELETED event: " + workItem.Key)
+			if workItem.Object != nil {
+				name = workItem.Object.GetName()
 			}
 			
+			// Use captured UID and annotations from WorkItem for DELETED events, falling back per DeleteUIDPolicy
 			uid := workItem.DeletedUID
+			if uid == "" {
+				fallbackUID, ok := c.fallbackDeleteUID(workItem.GVRString, namespace, name, workItem.Object)
+				if !ok {
+					c.logger.Error("controller", "No captured UID for DELETED event: "+workItem.Key)
+					return errors.New("no captured UID for DELETED event: " + workItem.Key)
+				}
+				uid = fallbackUID
+			}
 			annotations := workItem.DeletedAnnotations
 			c.logger.Debug("controller", fmt.Sprintf("Using captured DELETED metadata: UID=%s, annotations=%d", uid, len(annotations)))
 			
@@ -1379,8 +3870,15 @@ func (c *Controller) reconcile(workItem *WorkItem) error {
 				deletedObjForLogging.SetAnnotations(annotations)
 			}
 			
-			// Log JSON event for DELETE with captured metadata
-			c.logJSONEvent("DELETED", workItem.GVRString, namespace, name, uid, nil, deletedObjForLogging)
+			// Log JSON event for DELETE with captured metadata, attributed to the first config covering its namespace
+			configID := ""
+			for _, config := range workItem.Configs {
+				if configMatchesNamespace(config, namespace) {
+					configID = config.ConfigID
+					break
+				}
+			}
+			c.logJSONEvent(workItem.EventType, workItem.GVRString, namespace, name, uid, configID, nil, deletedObjForLogging)
 			
 			// Clean up UID from cache after processing
 			c.cleanupUIDFromInformerState(workItem.GVRString, namespace, name)
@@ -1394,30 +3892,21 @@ func (c *Controller) reconcile(workItem *WorkItem) error {
 			}
 			
 			// Call OnMatched handlers for DELETE events
+			resource, kind := c.discoveredResourceIdentity(workItem.GVRString)
 			for _, config := range workItem.Configs {
-				// RACE CONDITION FIX: Create a deep copy for event handlers to avoid concurrent access
 				matchedEvent := MatchedEvent{
-					EventType: "DELETED",
-					Object:    deletedObj.DeepCopy(), // Deep copy to prevent concurrent access by event handlers
+					EventType: workItem.EventType,
+					Object:    deletedObj, // Copied for handlers by dispatchMatchedEvent
 					GVR:       workItem.GVRString,
+					Resource:  resource,
+					Kind:      kind,
 					Key:       workItem.Key,
 					Config:    config,
-					Timestamp: time.Now(), // DELETE events don't have the full object, so use current time
+					Timestamp: c.now(), // DELETE events don't have the full object, so use current time
 				}
 				
 				// Call event handlers (non-blocking)
-				c.handlersMu.RLock()
-				handlers := c.eventHandlers
-				c.handlersMu.RUnlock()
-				
-				for _, handler := range handlers {
-					// Call handler in goroutine to avoid blocking Faro
-					go func(h EventHandler, event MatchedEvent) {
-						if err := h.OnMatched(event); err != nil {
-							c.logger.Warning("controller", fmt.Sprintf("Event handler failed for DELETE: %v", err))
-						}
-					}(handler, matchedEvent)
-				}
+				c.dispatchMatchedEvent(matchedEvent)
 				break // Only process once per object
 			}
 			
@@ -1435,45 +3924,97 @@ func (c *Controller) reconcile(workItem *WorkItem) error {
 	return c.processObject(workItem.EventType, unstructuredObj, workItem.GVRString, workItem.Configs)
 }
 
+// discoveredResourceIdentity returns the GroupVersionResource and Kind API discovery found for
+// gvrString, or zero values when the GVR was not discovered
+func (c *Controller) discoveredResourceIdentity(gvrString string) (schema.GroupVersionResource, string) {
+	c.discoveredResourcesMu.RLock()
+	defer c.discoveredResourcesMu.RUnlock()
+	info, found := c.discoveredResources[gvrString]
+	if !found {
+		return schema.GroupVersionResource{}, ""
+	}
+	return schema.GroupVersionResource{Group: info.Group, Version: info.Version, Resource: info.Resource}, info.Kind
+}
+
+// resyncJitterFraction is how much longer than ResyncPeriodSec an informer's resync period can be
+const resyncJitterFraction = 0.1
+
+// informerResyncPeriod returns the resync period of the informer with listerKey: ResyncPeriodSec
+// plus an offset of up to resyncJitterFraction of it. The offset is derived from the key, so
+// informers of different namespaces and GVRs relist at different times instead of all at once,
+// and a restarted informer keeps its period.
+func (c *Controller) informerResyncPeriod(listerKey string) time.Duration {
+	if c.config.ResyncPeriodSec <= 0 {
+		return 0
+	}
+	period := time.Duration(c.config.ResyncPeriodSec) * time.Second
+	maxOffset := int64(float64(period) * resyncJitterFraction)
+	if maxOffset <= 0 {
+		return period
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(listerKey))
+	return period + time.Duration(hash.Sum64()%uint64(maxOffset))
+}
+
+// configMatchesNamespace reports whether config covers objects in namespace
+func configMatchesNamespace(config NormalizedConfig, namespace string) bool {
+	if len(config.NamespaceNames) == 0 {
+		// No namespace names means match all namespaces
+		return true
+	}
+	for _, namespaceName := range config.NamespaceNames {
+		// Empty name means all namespaces, otherwise require an exact match
+		if namespaceName == "" || namespaceName == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 // processObject contains the core filtering and logging logic
 func (c *Controller) processObject(eventType string, obj *unstructured.Unstructured, gvrString string, configs []NormalizedConfig) error {
 	resourceName := obj.GetName()
 	resourceNamespace := obj.GetNamespace()
 	resourceUID := obj.GetUID()
 
+	// Why the last config was skipped, reported if none matches
+	filteredReason := ""
+
+	// Structured identity of the resource for handlers, from API discovery
+	resource, kind := c.discoveredResourceIdentity(gvrString)
+
 	// Apply namespace filtering when watching all namespaces
 	for _, config := range configs {
-		// Check if this config matches the resource's namespace
-		namespaceMatches := false
-		if len(config.NamespaceNames) == 0 {
-			// No namespace names means match all namespaces
-			namespaceMatches = true
-		} else {
-			// Check if resource namespace matches any of the configured names
-			for _, namespaceName := range config.NamespaceNames {
-				if namespaceName == "" {
-					// Empty name means all namespaces
-					namespaceMatches = true
-					break
-				} else if namespaceName == resourceNamespace {
-					// Exact namespace match
-					namespaceMatches = true
-					break
-				}
+		// Skip this config if namespace doesn't match
+		if !configMatchesNamespace(config, resourceNamespace) {
+			filteredReason = filterReasonNamespace
+			continue
+		}
+		
+		// Skip this config if the pod runs on another node; the spec.nodeName field selector
+		// is dropped when configs sharing the informer pin different nodes
+		if config.NodeName != "" {
+			if nodeName, _, _ := unstructured.NestedString(obj.Object, "spec", "nodeName"); nodeName != config.NodeName {
+				filteredReason = filterReasonNode
+				continue
 			}
 		}
 		
-		// Skip this config if namespace doesn't match
-		if !namespaceMatches {
+		// Skip this config if the object doesn't have the required field values
+		if !c.matchesJSONPathSelectors(obj, config.JSONPathSelectors) {
+			filteredReason = filterReasonJSONPath
 			continue
 		}
+		filteredReason = ""
 		
 		// Create matched event for handlers
-		// RACE CONDITION FIX: Create a deep copy for event handlers to avoid concurrent access
 		matchedEvent := MatchedEvent{
 			EventType: eventType,
-			Object:    obj.DeepCopy(), // Deep copy to prevent concurrent access by event handlers
+			Object:    obj, // Cached object - dispatchMatchedEvent copies it for handlers that may mutate it
 			GVR:       gvrString,
+			Resource:  resource,
+			Kind:      kind,
 			Key:       obj.GetNamespace() + "/" + obj.GetName(),
 			Config:    config,
 			Timestamp: obj.GetCreationTimestamp().Time,
@@ -1485,18 +4026,7 @@ func (c *Controller) processObject(eventType string, obj *unstructured.Unstructu
 		}
 		
 		// Call event handlers (non-blocking)
-		c.handlersMu.RLock()
-		handlers := c.eventHandlers
-		c.handlersMu.RUnlock()
-		
-		for _, handler := range handlers {
-			// Call handler in goroutine to avoid blocking Faro
-			go func(h EventHandler, event MatchedEvent) {
-				if err := h.OnMatched(event); err != nil {
-					c.logger.Warning("controller", fmt.Sprintf("Event handler failed: %v", err))
-				}
-			}(handler, matchedEvent)
-		}
+		c.dispatchMatchedEvent(matchedEvent)
 		
 		// Log the matched event (preserve existing behavior)
 		if resourceNamespace != "" {
@@ -1508,31 +4038,234 @@ func (c *Controller) processObject(eventType string, obj *unstructured.Unstructu
 		}
 		
 		// Log JSON event for export
-		c.logJSONEvent(eventType, gvrString, resourceNamespace, resourceName, string(resourceUID), obj.GetLabels(), obj)
+		c.logJSONEvent(eventType, gvrString, resourceNamespace, resourceName, string(resourceUID), config.ConfigID, obj.GetLabels(), obj)
 		
 		break // Only process once per object
 	}
 
+	if filteredReason != "" {
+		c.metrics.OnEventFiltered(gvrString, filteredReason)
+		c.logger.Debug("controller", fmt.Sprintf("Filtered %s event for %s %s/%s (reason: %s)", eventType, gvrString, resourceNamespace, resourceName, filteredReason))
+	}
+
 	return nil
 }
 
+// Reasons reported by faro_events_filtered_total. Name and label selectors are applied
+// server-side, so objects they exclude never reach the controller and aren't counted.
+const (
+	filterReasonNamespace = "namespace" // Object namespace not in any config's namespace_names
+	filterReasonNode      = "node"      // Pod not on the config's node_name (field selector not applied)
+	filterReasonJSONPath  = "jsonpath"  // Object fields don't match jsonpath_selectors
+	filterReasonSelf      = "self"      // UPDATED by SelfFieldManager (IgnoreSelfInducedChanges)
+)
+
+// lastFieldManager returns the manager of the most recent managedFields entry, or "" when
+// there is none or several managers share the latest (second-granularity) timestamp
+func lastFieldManager(obj *unstructured.Unstructured) string {
+	var manager string
+	var latest time.Time
+	ambiguous := false
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time == nil {
+			continue
+		}
+		switch {
+		case entry.Time.Time.After(latest):
+			latest = entry.Time.Time
+			manager = entry.Manager
+			ambiguous = false
+		case entry.Time.Time.Equal(latest) && entry.Manager != manager:
+			ambiguous = true
+		}
+	}
+	if ambiguous {
+		return ""
+	}
+	return manager
+}
+
+// isSelfInducedChange reports whether an UPDATED event was caused by the handlers' own
+// writes, identified by Config.SelfFieldManager
+func (c *Controller) isSelfInducedChange(eventType string, obj *unstructured.Unstructured) bool {
+	if !c.config.IgnoreSelfInducedChanges || eventType != "UPDATED" || obj == nil {
+		return false
+	}
+	return lastFieldManager(obj) == c.config.SelfFieldManager
+}
+
 // REMOVED: All client-side filtering functions have been eliminated from Faro core
 // Old event handler functions removed - replaced by handleUnifiedNormalizedEvent()
 
 
+// sampleEvent decides whether an event is kept under the configs' sample rates.
+// The most permissive rate wins; an unset (0) or full (1) rate keeps every event.
+func (c *Controller) sampleEvent(configs []NormalizedConfig) bool {
+	rate := 0.0
+	for _, config := range configs {
+		if config.SampleRate <= 0 || config.SampleRate >= 1 {
+			return true
+		}
+		if config.SampleRate > rate {
+			rate = config.SampleRate
+		}
+	}
+	if rate == 0 {
+		return true
+	}
+	return rand.Float64() < rate
+}
+
+// throttleEvent takes a token from the GVR's max_events_per_sec bucket and reports whether the
+// event may pass. The most permissive rate wins; an unset (0) rate keeps every event. The bucket
+// holds one second of events, so short bursts up to the rate pass unthrottled.
+func (c *Controller) throttleEvent(gvrString string, configs []NormalizedConfig) bool {
+	limit := 0.0
+	for _, config := range configs {
+		if config.MaxEventsPerSec <= 0 {
+			return true
+		}
+		limit = math.Max(limit, config.MaxEventsPerSec)
+	}
+	if limit == 0 {
+		return true
+	}
+	limiter, ok := c.eventLimiters.Load(gvrString)
+	if !ok {
+		limiter, _ = c.eventLimiters.LoadOrStore(gvrString, rate.NewLimiter(rate.Limit(limit), int(math.Max(1, math.Ceil(limit)))))
+	}
+	return limiter.(*rate.Limiter).Allow()
+}
+
+// jsonPathFields returns the field path for a dotted JSONPathSelectors path, compiling it once
+func (c *Controller) jsonPathFields(path string) []string {
+	if fields, ok := c.jsonPaths.Load(path); ok {
+		return fields.([]string)
+	}
+	fields := strings.Split(path, ".")
+	c.jsonPaths.Store(path, fields)
+	return fields
+}
+
+// matchesJSONPathSelectors reports whether every selector path holds a scalar equal to its value.
+// Missing fields, maps and lists never match.
+func (c *Controller) matchesJSONPathSelectors(obj *unstructured.Unstructured, selectors map[string]string) bool {
+	for path, expected := range selectors {
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, c.jsonPathFields(path)...)
+		if err != nil || !found {
+			return false
+		}
+		switch value.(type) {
+		case string, bool, int64, float64:
+			if fmt.Sprint(value) != expected {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAnyJSONPathSelectors reports whether the object passes the JSONPathSelectors of at least one config
+func (c *Controller) matchesAnyJSONPathSelectors(obj *unstructured.Unstructured, configs []NormalizedConfig) bool {
+	if len(configs) == 0 {
+		return true
+	}
+	for _, config := range configs {
+		if c.matchesJSONPathSelectors(obj, config.JSONPathSelectors) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorDedupEntries bounds the events remembered for OR-ed LabelSelectors deduplication
+const selectorDedupEntries = 10000
+
+// isDuplicateSelectorEvent reports whether another label selector's informer already queued
+// this event, identified by UID, event type and resourceVersion, and remembers it otherwise
+func (c *Controller) isDuplicateSelectorEvent(eventType string, obj *unstructured.Unstructured) bool {
+	if obj == nil || obj.GetUID() == "" {
+		return false
+	}
+	key := string(obj.GetUID()) + "|" + eventType + "|" + obj.GetResourceVersion()
+
+	c.selectorDedupMu.Lock()
+	defer c.selectorDedupMu.Unlock()
+	if _, seen := c.selectorDedup.Load(key); seen {
+		return true
+	}
+	c.selectorDedup.Store(key, "")
+	return false
+}
+
 // handleNamespaceSpecificEvent processes events from namespace-specific informers
-func (c *Controller) handleNamespaceSpecificEvent(eventType string, obj *unstructured.Unstructured, gvrString string, configs []NormalizedConfig) {
+func (c *Controller) handleNamespaceSpecificEvent(eventType string, obj *unstructured.Unstructured, gvrString string, configs []NormalizedConfig, listerKey string) {
 	// Use the same event handling as the unified informer
-	c.handleUnifiedNormalizedEvent(eventType, obj, gvrString, configs)
+	c.handleUnifiedNormalizedEvent(eventType, obj, gvrString, configs, listerKey)
 }
 
 // handleUnifiedNormalizedEvent processes events with multiple normalized config-based filtering
 // handleUnifiedNormalizedEvent is a lightweight event handler that only enqueues work items
-func (c *Controller) handleUnifiedNormalizedEvent(eventType string, obj *unstructured.Unstructured, gvrString string, normalizedConfigs []NormalizedConfig) {
+func (c *Controller) handleUnifiedNormalizedEvent(eventType string, obj *unstructured.Unstructured, gvrString string, normalizedConfigs []NormalizedConfig, listerKey string) {
 	// Extract the object key - this is the only work done in the event handler
 	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err == nil && obj.GetName() == "" {
+		err = errors.New("object has no name")
+	}
+	var fallbackObj *unstructured.Unstructured
 	if err != nil {
-		c.logger.Error("controller", fmt.Sprintf("Failed to get key for object: %v", err))
+		c.metrics.OnKeyFuncError(gvrString)
+		if obj == nil || obj.GetUID() == "" {
+			c.logger.Error("controller", fmt.Sprintf("Failed to get key for %s object, dropping %s event: %v", gvrString, eventType, err))
+			return
+		}
+		// Key the object by UID instead and carry it in the work item, so the event isn't lost
+		key = uidFallbackKey(obj)
+		fallbackObj = obj
+		c.logger.Warning("controller", fmt.Sprintf("Failed to get key for %s object, using %s: %v", gvrString, key, err))
+	}
+
+	// The state-tracking handlers already updated the UID cache; a paused delete is never
+	// processed, so release its UID here
+	if c.paused.Load() {
+		if eventType == "DELETED" {
+			c.cleanupUIDFromInformerState(gvrString, obj.GetNamespace(), obj.GetName())
+		}
+		c.logger.Debug("controller", fmt.Sprintf("Paused, dropping %s event for %s %s", eventType, gvrString, key))
+		return
+	}
+
+	// Probabilistically drop ADDED/UPDATED events for sampled resources - deletions are always kept
+	if eventType != "DELETED" && !c.sampleEvent(normalizedConfigs) {
+		c.metrics.OnEventSampled(gvrString)
+		return
+	}
+
+	// Drop ADDED/UPDATED events above the GVR's max_events_per_sec instead of queueing them
+	if eventType != "DELETED" && !c.throttleEvent(gvrString, normalizedConfigs) {
+		c.metrics.OnEventThrottled(gvrString)
+		return
+	}
+
+	// Changes written by the handlers themselves would otherwise trigger them again
+	if c.isSelfInducedChange(eventType, obj) {
+		c.metrics.OnEventFiltered(gvrString, filterReasonSelf)
+		c.logger.Debug("controller", fmt.Sprintf("Skipping UPDATED event for %s %s - last changed by field manager %s", gvrString, key, c.config.SelfFieldManager))
+		return
+	}
+
+	// Deletions skip processObject, so filter them on the final object state here
+	if eventType == "DELETED" && !c.matchesAnyJSONPathSelectors(obj, normalizedConfigs) {
+		c.metrics.OnEventFiltered(gvrString, filterReasonJSONPath)
+		c.logger.Debug("controller", fmt.Sprintf("Skipping DELETED event for %s %s - no jsonpath_selectors match", gvrString, key))
+		return
+	}
+
+	// Objects matching several OR-ed label selectors arrive once per informer - queue them once
+	if len(normalizedConfigs) > 0 && len(normalizedConfigs[0].LabelSelectors) > 1 && c.isDuplicateSelectorEvent(eventType, obj) {
+		c.logger.Debug("controller", fmt.Sprintf("Skipping duplicate %s event for %s %s from overlapping label selectors", eventType, gvrString, key))
 		return
 	}
 
@@ -1542,6 +4275,8 @@ func (c *Controller) handleUnifiedNormalizedEvent(eventType string, obj *unstruc
 		GVRString: gvrString,
 		Configs:   normalizedConfigs,
 		EventType: eventType,
+		ListerKey: listerKey,
+		Object:    fallbackObj,
 	}
 
 	// For DELETED events, capture UID and annotations before they're lost
@@ -1552,6 +4287,95 @@ func (c *Controller) handleUnifiedNormalizedEvent(eventType string, obj *unstruc
 	}
 
 	c.logger.Debug("controller", fmt.Sprintf("Queueing %s event for %s %s", eventType, gvrString, key))
-	c.workQueue.Add(workItem)
+	if c.debouncer != nil {
+		c.debouncer.Add(workItem)
+		return
+	}
+	c.enqueueWorkItem(workItem)
+}
+
+// uidFallbackKey builds a work item key from the UID of an object whose namespace/name key
+// can't be computed; it still splits like a namespace key (namespace/uid:<uid>)
+func uidFallbackKey(obj *unstructured.Unstructured) string {
+	if namespace := obj.GetNamespace(); namespace != "" {
+		return namespace + "/uid:" + string(obj.GetUID())
+	}
+	return "uid:" + string(obj.GetUID())
+}
+
+// workQueueKey is the work queue entry for the object a work item refers to
+func workQueueKey(workItem *WorkItem) string {
+	if workItem.EventType == "SYNCED" {
+		return workItem.ListerKey + "|SYNCED" // Key is a namespace, which may equal a cluster-scoped object name
+	}
+	return workItem.GVRString + "|" + workItem.Key
+}
+
+// enqueueWorkItem records the work item behind any pending ones for the same object and queues the object key
+func (c *Controller) enqueueWorkItem(workItem *WorkItem) {
+	key := workQueueKey(workItem)
+
+	c.pendingItemsMu.Lock()
+	c.pendingItems[key] = append(c.pendingItems[key], workItem)
+	c.pendingItemsMu.Unlock()
+
+	c.workQueue.Add(key)
+}
+
+// takePendingItems removes and returns the work items queued for an object key
+func (c *Controller) takePendingItems(key string) []*WorkItem {
+	c.pendingItemsMu.Lock()
+	defer c.pendingItemsMu.Unlock()
+
+	items := c.pendingItems[key]
+	delete(c.pendingItems, key)
+	c.inFlightItems += len(items)
+	return items
+}
+
+// finishPendingItems marks work items taken with takePendingItems as processed (or requeued)
+func (c *Controller) finishPendingItems(count int) {
+	c.pendingItemsMu.Lock()
+	defer c.pendingItemsMu.Unlock()
+
+	c.inFlightItems -= count
+}
+
+// PendingWorkItems returns the number of work items queued or being processed, including
+// failed ones waiting for a retry. Once it reaches zero every event seen so far has been
+// reconciled and exported to JSON, so tests can poll it instead of sleeping. Events held by
+// DedupWindowMs aren't queued yet, and handlers may still be running in their own goroutines.
+func (c *Controller) PendingWorkItems() int {
+	c.pendingItemsMu.Lock()
+	defer c.pendingItemsMu.Unlock()
+
+	pending := c.inFlightItems
+	for _, items := range c.pendingItems {
+		pending += len(items)
+	}
+	return pending
+}
+
+// requeueBackoffItems adds the key of every object with pending work items to the queue
+// right away, including failed ones whose AddRateLimited retry is still waiting
+func (c *Controller) requeueBackoffItems() {
+	c.pendingItemsMu.Lock()
+	keys := make([]string, 0, len(c.pendingItems))
+	for key := range c.pendingItems {
+		keys = append(keys, key)
+	}
+	c.pendingItemsMu.Unlock()
+
+	for _, key := range keys {
+		c.workQueue.Add(key)
+	}
+}
+
+// requeuePendingItems puts unprocessed work items back ahead of any queued since they were taken
+func (c *Controller) requeuePendingItems(key string, items []*WorkItem) {
+	c.pendingItemsMu.Lock()
+	defer c.pendingItemsMu.Unlock()
+
+	c.pendingItems[key] = append(items, c.pendingItems[key]...)
 }
 
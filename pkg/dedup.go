@@ -0,0 +1,155 @@
+package faro
+
+import (
+	"sync"
+	"time"
+)
+
+// Dedup modes for collapsing rapid events on the same object
+const (
+	DedupModeTrailing = "trailing" // Emit the latest event once the window closes (default)
+	DedupModeLeading  = "leading"  // Emit the first event immediately, drop the rest of the window
+)
+
+// EventTypeEphemeral reports an object created and deleted within one dedup window
+// (CoalesceShortLived); it carries the DELETED event's metadata
+const EventTypeEphemeral = "EPHEMERAL"
+
+// Short-lived modes: how CoalesceShortLived reports an object deleted before its ADDED was emitted
+const (
+	ShortLivedModeEphemeral = "ephemeral" // One EPHEMERAL event instead of ADDED+DELETED (default)
+	ShortLivedModeDrop      = "drop"      // No event at all
+)
+
+// eventDebouncer coalesces work items for the same GVR+key within a time window
+// before they reach the work queue. DELETED events always flush immediately.
+type eventDebouncer struct {
+	window  time.Duration
+	leading bool
+	emit    func(*WorkItem)
+
+	coalesceShortLived bool            // Replace a pending ADDED and its DELETED with one EPHEMERAL item
+	dropShortLived     bool            // ...or with nothing
+	discard            func(*WorkItem) // Called with the DELETED item of a dropped short-lived object
+
+	mu      sync.Mutex
+	pending map[string]*debounceEntry
+	stopped bool // Windows are closed: Add emits at once
+}
+
+// debounceEntry holds the window state for a single object
+type debounceEntry struct {
+	item  *WorkItem // Latest item to emit when the window closes (nil in leading mode)
+	timer *time.Timer
+}
+
+// newEventDebouncer creates a debouncer forwarding collapsed items to emit
+func newEventDebouncer(window time.Duration, mode string, emit func(*WorkItem)) *eventDebouncer {
+	return &eventDebouncer{
+		window:  window,
+		leading: mode == DedupModeLeading,
+		emit:    emit,
+		pending: make(map[string]*debounceEntry),
+	}
+}
+
+// Add submits a work item, emitting it now, later, or never depending on the window state
+func (d *eventDebouncer) Add(item *WorkItem) {
+	key := item.GVRString + "|" + item.Key
+
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		d.emit(item)
+		return
+	}
+	entry, exists := d.pending[key]
+
+	// Deletions flush whatever is pending and bypass the window
+	if item.EventType == "DELETED" {
+		var flushed *WorkItem
+		if exists {
+			entry.timer.Stop()
+			flushed = entry.item
+			delete(d.pending, key)
+		}
+		d.mu.Unlock()
+
+		// The object's creation was never emitted: report its whole life as one event, or not at all
+		if d.coalesceShortLived && flushed != nil && flushed.EventType == "ADDED" {
+			if d.dropShortLived {
+				d.discard(item)
+				return
+			}
+			item.EventType = EventTypeEphemeral
+			d.emit(item)
+			return
+		}
+
+		if flushed != nil {
+			d.emit(flushed)
+		}
+		d.emit(item)
+		return
+	}
+
+	if exists {
+		// Collapse into the open window; keep ADDED so creations aren't reported as updates
+		if entry.item != nil {
+			if entry.item.EventType == "ADDED" {
+				item.EventType = "ADDED"
+			}
+			entry.item = item
+		}
+		d.mu.Unlock()
+		return
+	}
+
+	entry = &debounceEntry{}
+	if !d.leading {
+		entry.item = item
+	}
+	entry.timer = time.AfterFunc(d.window, func() { d.flush(key, entry) })
+	d.pending[key] = entry
+	d.mu.Unlock()
+
+	if d.leading {
+		d.emit(item)
+	}
+}
+
+// flush closes the window for key and emits the collapsed item (trailing mode)
+func (d *eventDebouncer) flush(key string, entry *debounceEntry) {
+	d.mu.Lock()
+	if d.pending[key] != entry {
+		d.mu.Unlock()
+		return // Already flushed by a DELETED event
+	}
+	delete(d.pending, key)
+	item := entry.item
+	d.mu.Unlock()
+
+	if item != nil {
+		d.emit(item)
+	}
+}
+
+// Stop closes all open windows, emitting their collapsed items, and makes later Add calls
+// emit at once, so events seen while the controller shuts down still reach the work queue
+func (d *eventDebouncer) Stop() {
+	d.mu.Lock()
+	d.stopped = true
+	var items []*WorkItem
+	for key, entry := range d.pending {
+		entry.timer.Stop()
+		delete(d.pending, key)
+		if entry.item != nil {
+			items = append(items, entry.item)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, item := range items {
+		d.emit(item)
+	}
+}
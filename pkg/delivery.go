@@ -0,0 +1,111 @@
+package faro
+
+import (
+	"time"
+)
+
+// Policies for a full handler queue (HandlerQueuePolicy)
+const (
+	HandlerQueuePolicyDrop  = "drop"  // Drop the event for that handler and count it (default)
+	HandlerQueuePolicyBlock = "block" // Block the worker until the handler's queue has room
+)
+
+// Policies for when all MaxHandlerConcurrency slots are busy (HandlerConcurrencyPolicy)
+const (
+	HandlerConcurrencyPolicyDrop  = "drop"  // Drop the event for that handler and count it (default)
+	HandlerConcurrencyPolicyBlock = "block" // Block the worker until a slot is free
+)
+
+// deliveryQueue buffers matched events for one EventHandler in a bounded channel drained by
+// a single delivery goroutine, so a slow handler costs one goroutine and HandlerQueueSize
+// events instead of a goroutine per event. Events are delivered in order.
+type deliveryQueue struct {
+	invoke func(event MatchedEvent) error // Calls the handler
+	block  bool
+	onDone func(event MatchedEvent, duration time.Duration, err error) // Called after every OnMatched
+	onDrop func()                                                      // Called for every event dropped on a full queue
+
+	events chan MatchedEvent
+	quit   chan struct{}
+	done   chan struct{}
+}
+
+// newDeliveryQueue creates a queue of size events delivered with invoke and starts its delivery goroutine
+func newDeliveryQueue(invoke func(event MatchedEvent) error, size int, policy string, onDone func(event MatchedEvent, duration time.Duration, err error), onDrop func()) *deliveryQueue {
+	q := &deliveryQueue{
+		invoke: invoke,
+		block:  policy == HandlerQueuePolicyBlock,
+		onDone: onDone,
+		onDrop: onDrop,
+		events: make(chan MatchedEvent, size),
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go q.deliver()
+	return q
+}
+
+// Add queues an event, dropping or blocking per policy when the queue is full
+func (q *deliveryQueue) Add(event MatchedEvent) {
+	select {
+	case <-q.quit:
+		return
+	default:
+	}
+
+	if q.block {
+		select {
+		case q.events <- event:
+		case <-q.quit:
+		}
+		return
+	}
+
+	select {
+	case q.events <- event:
+	default:
+		if q.onDrop != nil {
+			q.onDrop()
+		}
+	}
+}
+
+// deliver calls the handler for each queued event until the queue is stopped, then
+// delivers whatever is still buffered
+func (q *deliveryQueue) deliver() {
+	defer close(q.done)
+	for {
+		select {
+		case event := <-q.events:
+			q.call(event)
+		case <-q.quit:
+			for {
+				select {
+				case event := <-q.events:
+					q.call(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// call delivers one event to the handler
+func (q *deliveryQueue) call(event MatchedEvent) {
+	started := time.Now()
+	err := q.invoke(event)
+	if q.onDone != nil {
+		q.onDone(event, time.Since(started), err)
+	}
+}
+
+// Stop stops accepting events and waits until the buffered ones have been delivered
+func (q *deliveryQueue) Stop() {
+	select {
+	case <-q.quit:
+	default:
+		close(q.quit)
+	}
+	<-q.done
+}
@@ -0,0 +1,88 @@
+package faro
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DiscoveryCache shares API discovery results between controllers watching the same cluster
+// (NewControllerWithDiscovery), so starting several controllers walks the API server's groups
+// once instead of once per controller. Results are reused until the TTL expires or Invalidate
+// is called; controllers starting while discovery runs wait for it instead of starting their own.
+type DiscoveryCache struct {
+	ttl time.Duration
+
+	mu          sync.Mutex
+	result      *discoveryResult // Results of the last discovery (nil = none yet)
+	fetchedAt   time.Time
+	inFlight    chan struct{} // Closed when the running discovery finishes (nil = none running)
+	discoveries int
+}
+
+// discoveryResult is what one discovery found, shared by every controller using the cache
+type discoveryResult struct {
+	resources   map[string]ResourceInfo       // map[GVR] -> ResourceInfo
+	preferred   map[string]string             // map[group] -> preferred version
+	unavailable map[schema.GroupVersion]error // Group versions whose discovery failed, retried by each controller
+}
+
+// NewDiscoveryCache creates an empty cache whose results expire after ttl (0 = never expire)
+func NewDiscoveryCache(ttl time.Duration) *DiscoveryCache {
+	return &DiscoveryCache{ttl: ttl}
+}
+
+// Invalidate drops the cached results; the next controller to start runs discovery again
+func (d *DiscoveryCache) Invalidate() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.result = nil
+}
+
+// Discoveries returns how many times the cache ran discovery against the API server
+func (d *DiscoveryCache) Discoveries() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.discoveries
+}
+
+// get returns the cached results, running discover to fill the cache when it is empty or
+// expired. Only one discover runs at a time; if it fails, the next waiter tries again.
+func (d *DiscoveryCache) get(ctx context.Context, discover func() (*discoveryResult, error)) (*discoveryResult, error) {
+	for {
+		d.mu.Lock()
+		if d.result != nil && (d.ttl <= 0 || time.Since(d.fetchedAt) < d.ttl) {
+			result := d.result
+			d.mu.Unlock()
+			return result, nil
+		}
+		if d.inFlight == nil {
+			break
+		}
+		inFlight := d.inFlight
+		d.mu.Unlock()
+
+		select {
+		case <-inFlight:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	done := make(chan struct{})
+	d.inFlight = done
+	d.discoveries++
+	d.mu.Unlock()
+
+	result, err := discover()
+
+	d.mu.Lock()
+	if err == nil {
+		d.result, d.fetchedAt = result, time.Now()
+	}
+	d.inFlight = nil
+	close(done)
+	d.mu.Unlock()
+	return result, err
+}
@@ -0,0 +1,50 @@
+package faro
+
+import (
+	"sync"
+	"time"
+)
+
+// eventRing keeps the last matched events for replay (EventRingSize), overwriting the oldest
+// once full so memory stays bounded by the ring size
+type eventRing struct {
+	mu     sync.Mutex
+	events []ringEntry // Ring storage, next is the slot written next
+	next   int
+	count  int
+}
+
+// ringEntry is a buffered event with the time it was dispatched; MatchedEvent.Timestamp is the
+// object's creation time for most events, so it can't order the ring
+type ringEntry struct {
+	event      MatchedEvent
+	dispatched time.Time
+}
+
+// newEventRing creates a ring holding up to size events
+func newEventRing(size int) *eventRing {
+	return &eventRing{events: make([]ringEntry, size)}
+}
+
+// add records an event, dropping the oldest when the ring is full
+func (r *eventRing) add(event MatchedEvent, dispatched time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[r.next] = ringEntry{event: event, dispatched: dispatched}
+	r.next = (r.next + 1) % len(r.events)
+	if r.count < len(r.events) {
+		r.count++
+	}
+}
+
+// entries returns the buffered entries, oldest first
+func (r *eventRing) entries() []ringEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]ringEntry, 0, r.count)
+	start := (r.next - r.count + len(r.events)) % len(r.events)
+	for i := 0; i < r.count; i++ {
+		entries = append(entries, r.events[(start+i)%len(r.events)])
+	}
+	return entries
+}
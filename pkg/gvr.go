@@ -0,0 +1,48 @@
+package faro
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ParseGVR parses a GVR string as used in ResourceConfig.GVR and MatchedEvent.GVR: core
+// resources are "version/resource" ("v1/configmaps"), all others "group/version/resource"
+// ("batch/v1/jobs"). Resources are the lowercase plural names API discovery reports.
+func ParseGVR(s string) (schema.GroupVersionResource, error) {
+	group, version, resource, ok := splitGVR(s)
+	if !ok {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid GVR '%s', must be version/resource (core group) or group/version/resource", s)
+	}
+	if strings.ContainsAny(s, " \t\n") {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid GVR '%s', must not contain whitespace", s)
+	}
+	if strings.Count(s, "/") == 2 && group == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid GVR '%s', group must not be empty, use version/resource for the core group", s)
+	}
+	if version == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid GVR '%s', version must not be empty", s)
+	}
+	if resource == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid GVR '%s', resource must not be empty", s)
+	}
+	if resource != strings.ToLower(resource) {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid GVR '%s', resource must be the lowercase plural name (e.g. %s)", s, strings.ToLower(resource))
+	}
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: resource}, nil
+}
+
+// splitGVR splits a two-part (core group) or three-part GVR string without validating the
+// parts, which may be empty; ok is false for any other number of parts
+func splitGVR(s string) (group, version, resource string, ok bool) {
+	parts := strings.Split(s, "/")
+	switch len(parts) {
+	case 2:
+		return "", parts[0], parts[1], true // Core group ("v1/configmaps")
+	case 3:
+		return parts[0], parts[1], parts[2], true
+	default:
+		return "", "", "", false
+	}
+}
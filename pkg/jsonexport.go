@@ -0,0 +1,91 @@
+package faro
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// jsonExportFile is one JSON export file: the main events-<timestamp>.json or, with
+// JsonPartitionBy, a partition's events-<value>-<timestamp>.json
+type jsonExportFile struct {
+	file     *os.File
+	path     string
+	offset   int64            // Bytes written to file so far
+	index    *jsonIndexWriter // Offset/time sidecar for SeekEvents (nil = disabled)
+	segments int              // Number of segments rotated so far
+}
+
+// openJSONExportFile opens (or appends to) the export file at path and, with a non-zero
+// indexInterval, its index sidecar
+func openJSONExportFile(path string, indexInterval time.Duration) (*jsonExportFile, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JSON log file %s: %w", path, err)
+	}
+	f := &jsonExportFile{file: file, path: path}
+	if info, err := file.Stat(); err == nil {
+		f.offset = info.Size()
+	}
+	if indexInterval > 0 {
+		index, err := newJSONIndexWriter(path+jsonIndexSuffix, indexInterval)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		f.index = index
+	}
+	return f, nil
+}
+
+// write appends one line, indexing its offset first. Callers serialize access.
+func (f *jsonExportFile) write(line string) {
+	if f.index != nil {
+		f.index.Record(f.offset, time.Now())
+	}
+	written, _ := f.file.WriteString(line + "\n")
+	f.offset += int64(written)
+	f.file.Sync() // Ensure immediate write
+}
+
+// close fsyncs and closes the file and its index sidecar
+func (f *jsonExportFile) close() {
+	f.file.Sync()
+	f.file.Close()
+	if f.index != nil {
+		f.index.Close()
+		f.index = nil
+	}
+}
+
+// jsonExportTarget returns the file a JSON line belongs in: the partition named by the
+// JsonPartitionBy label of the event, opened on first use, or the main file. Callers hold l.mu.
+func (l *Logger) jsonExportTarget(message string) (*jsonExportFile, error) {
+	if l.jsonPartitionBy == "" {
+		return l.jsonExport, nil
+	}
+
+	var event struct {
+		Labels map[string]string `json:"labels"`
+	}
+	json.Unmarshal([]byte(message), &event)
+	partition := event.Labels[l.jsonPartitionBy]
+	// Label values can't contain path separators, but the export must not escape its directory
+	if partition == "" || strings.ContainsAny(partition, `/\`) || partition == "." || partition == ".." {
+		return l.jsonExport, nil
+	}
+
+	if f, ok := l.jsonPartitions[partition]; ok {
+		return f, nil
+	}
+	path := filepath.Join(filepath.Dir(l.jsonExport.path), fmt.Sprintf("events-%s-%s.json", partition, l.jsonTimestamp))
+	f, err := openJSONExportFile(path, l.jsonIndexInterval)
+	if err != nil {
+		return nil, err
+	}
+	l.jsonPartitions[partition] = f
+	return f, nil
+}
@@ -0,0 +1,92 @@
+package faro
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// jsonIndexSuffix is appended to the JSON export path to name its index sidecar
+const jsonIndexSuffix = ".idx"
+
+// jsonIndexEntry maps a byte offset in the JSON export to the time the line there was written
+type jsonIndexEntry struct {
+	Offset int64     `json:"offset"`
+	Time   time.Time `json:"time"`
+}
+
+// jsonIndexWriter appends index entries to the sidecar, at most one per interval
+type jsonIndexWriter struct {
+	file     *os.File
+	interval time.Duration
+	last     time.Time
+}
+
+// newJSONIndexWriter opens (or appends to) the index sidecar at path
+func newJSONIndexWriter(path string, interval time.Duration) (*jsonIndexWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JSON index file: %w", err)
+	}
+	return &jsonIndexWriter{file: file, interval: interval}, nil
+}
+
+// Record indexes the line about to be written at offset, unless the previous entry is
+// younger than the interval. Callers serialize access.
+func (w *jsonIndexWriter) Record(offset int64, now time.Time) {
+	if !w.last.IsZero() && now.Sub(w.last) < w.interval {
+		return
+	}
+	line, err := json.Marshal(jsonIndexEntry{Offset: offset, Time: now})
+	if err != nil {
+		return
+	}
+	w.file.Write(append(line, '\n'))
+	w.last = now
+}
+
+// Close closes the sidecar file
+func (w *jsonIndexWriter) Close() error {
+	return w.file.Close()
+}
+
+// SeekEvents opens a JSON export file written with JsonIndexIntervalMs and positions it at the
+// last indexed line written at or before since. Events written up to one index interval before
+// since may therefore be included; callers filter on the event timestamps if they need exact bounds.
+func SeekEvents(path string, since time.Time) (io.ReadCloser, error) {
+	index, err := os.Open(path + jsonIndexSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JSON index: %w", err)
+	}
+	defer index.Close()
+
+	var offset int64
+	scanner := bufio.NewScanner(index)
+	for scanner.Scan() {
+		var entry jsonIndexEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid JSON index entry %q: %w", scanner.Text(), err)
+		}
+		// Entries are appended in write order, so the last one not after since wins
+		if entry.Time.After(since) {
+			break
+		}
+		offset = entry.Offset
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read JSON index: %w", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JSON export: %w", err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to seek JSON export: %w", err)
+	}
+	return file, nil
+}
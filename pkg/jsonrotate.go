@@ -0,0 +1,104 @@
+package faro
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// jsonArchiveSuffix is appended to closed JSON export segments when JsonCompressRotated is set
+const jsonArchiveSuffix = ".gz"
+
+// RotateJSON closes the current JSON export segment and continues in a fresh file at the same
+// path. The closed segment is kept as events-<timestamp>-<n>.json (numbered from 001, so
+// segments sort in write order before the active file) and, with JsonCompressRotated, gzipped
+// to .json.gz in the background. LogJSON rotates by itself once JsonRotateSizeMB is reached.
+// With JsonPartitionBy every partition file is rotated too.
+func (l *Logger) RotateJSON() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.jsonExport == nil {
+		return nil
+	}
+	if err := l.rotateJSONLocked(l.jsonExport); err != nil {
+		return err
+	}
+	for _, f := range l.jsonPartitions {
+		if err := l.rotateJSONLocked(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotateJSONLocked renames the active segment of f (and its index sidecar) and reopens its
+// path. Callers hold l.mu. If the rename fails the export keeps appending to the old file.
+func (l *Logger) rotateJSONLocked(f *jsonExportFile) error {
+	f.close()
+
+	f.segments++
+	archive := fmt.Sprintf("%s-%03d.json", strings.TrimSuffix(f.path, ".json"), f.segments)
+	renameErr := os.Rename(f.path, archive)
+	if renameErr == nil && l.jsonIndexInterval > 0 {
+		os.Rename(f.path+jsonIndexSuffix, archive+jsonIndexSuffix)
+	}
+
+	reopened, err := openJSONExportFile(f.path, l.jsonIndexInterval)
+	if err != nil {
+		return fmt.Errorf("failed to reopen JSON log file %s: %w", f.path, err)
+	}
+	f.file, f.offset, f.index = reopened.file, reopened.offset, reopened.index
+	if renameErr != nil {
+		return fmt.Errorf("failed to rotate JSON log file %s: %w", f.path, renameErr)
+	}
+
+	if l.jsonCompress {
+		l.archiveWG.Add(1)
+		go func() {
+			defer l.archiveWG.Done()
+			if err := compressJSONArchive(archive); err != nil {
+				klog.Warningf("[logger] Failed to compress JSON archive %s: %v", archive, err)
+			}
+		}()
+	}
+	return nil
+}
+
+// compressJSONArchive gzips a closed segment to path.gz and removes the original and its index
+// (SeekEvents needs the uncompressed file). On failure the uncompressed segment is left in place.
+func compressJSONArchive(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmpPath := path + jsonArchiveSuffix + ".tmp"
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path+jsonArchiveSuffix)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	os.Remove(path)
+	os.Remove(path + jsonIndexSuffix)
+	return nil
+}
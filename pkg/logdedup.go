@@ -0,0 +1,99 @@
+package faro
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// logDeduplicator suppresses repeats of the same (component, message) within a window.
+// The first occurrence is logged immediately; when the window closes, the number of
+// suppressed repeats is reported once and a new window starts if repeats keep coming.
+type logDeduplicator struct {
+	window time.Duration
+	emit   func(line string)
+
+	mu      sync.Mutex
+	entries map[uint64]*logDedupEntry
+	stopped bool
+}
+
+// logDedupEntry tracks one open suppression window
+type logDedupEntry struct {
+	line       string
+	suppressed int
+	timer      *time.Timer
+}
+
+// newLogDeduplicator creates a deduplicator writing lines through emit
+func newLogDeduplicator(window time.Duration, emit func(line string)) *logDeduplicator {
+	return &logDeduplicator{
+		window:  window,
+		emit:    emit,
+		entries: make(map[uint64]*logDedupEntry),
+	}
+}
+
+// logDedupKey hashes component and message into the suppression key
+func logDedupKey(component, message string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(component))
+	h.Write([]byte{0})
+	h.Write([]byte(message))
+	return h.Sum64()
+}
+
+// Log emits line unless an identical message was logged within the window
+func (d *logDeduplicator) Log(component, message, line string) {
+	key := logDedupKey(component, message)
+
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		return
+	}
+	if entry, exists := d.entries[key]; exists {
+		entry.suppressed++
+		d.mu.Unlock()
+		return
+	}
+	entry := &logDedupEntry{line: line}
+	entry.timer = time.AfterFunc(d.window, func() { d.closeWindow(key, entry) })
+	d.entries[key] = entry
+	d.mu.Unlock()
+
+	d.emit(line)
+}
+
+// closeWindow reports suppressed repeats and keeps the window open while they continue
+func (d *logDeduplicator) closeWindow(key uint64, entry *logDedupEntry) {
+	d.mu.Lock()
+	if d.stopped || d.entries[key] != entry {
+		d.mu.Unlock()
+		return
+	}
+	suppressed := entry.suppressed
+	if suppressed == 0 {
+		delete(d.entries, key)
+		d.mu.Unlock()
+		return
+	}
+	entry.suppressed = 0
+	entry.timer.Reset(d.window)
+	d.mu.Unlock()
+
+	d.emit(fmt.Sprintf("%s (%d occurrences suppressed in the last %s)", entry.line, suppressed, d.window))
+}
+
+// Stop cancels all open windows without reporting them
+func (d *logDeduplicator) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopped = true
+	for key, entry := range d.entries {
+		entry.timer.Stop()
+		delete(d.entries, key)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -14,29 +15,76 @@ import (
 
 var klogInitOnce sync.Once
 
-// Logger provides logging using klog directly
+// defaultJSONComponents are the components whose JSON messages always reach the export file
+var defaultJSONComponents = []string{"controller", "cluster-handler"}
+
+// ErrorRecord is one line of the errors-*.json export (JsonErrorExport)
+type ErrorRecord struct {
+	Timestamp string            `json:"timestamp"`
+	Level     string            `json:"level"`          // "error" or "warning"
+	Component string            `json:"component"`
+	Type      string            `json:"type,omitempty"` // e.g. "watch_error", "reconcile_failed" ("" = plain Error log)
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"` // Structured context such as gvr, namespace or key
+	Source    string            `json:"source,omitempty"` // Prefix of the logger that recorded it (LogPrefix)
+}
+
+// Logger provides logging using klog directly. Loggers derived with WithPrefix share the
+// files and settings of the logger they were derived from.
 type Logger struct {
-	jsonFile    *os.File
-	logWriter   io.Writer  // Writer for log output (stderr + file)
-	mu          sync.RWMutex
+	*loggerState
+	prefix string // Prepended to the component of every line (WithPrefix)
+}
+
+// loggerState is the output state shared by a logger and its prefixed copies
+type loggerState struct {
+	jsonExport     *jsonExportFile // events-*.json export (nil = disabled)
+	errorFile      *os.File // errors-*.json export of error records (nil = disabled)
+	jsonPartitionBy string         // Label key routing events to per-value files (JsonPartitionBy)
+	jsonPartitions map[string]*jsonExportFile // Partition files by label value, opened on first event
+	jsonTimestamp  string          // Timestamp of the export file names, shared by partitions
+	jsonIndexInterval time.Duration // JsonIndexIntervalMs (0 = no index)
+	jsonRotateSize int64           // Rotate the JSON export at this many bytes (0 = never)
+	jsonCompress   bool            // Gzip rotated segments (JsonCompressRotated)
+	archiveWG      sync.WaitGroup  // Background compressions of rotated segments
+	jsonComponents map[string]bool // Components allowed into the JSON export
+	logWriter      io.Writer       // Writer for log output (stderr + file)
+	errorDedup     *logDeduplicator // Collapses repeated identical errors (nil = disabled)
+	debugEnabled   bool            // LogLevel is debug; independent of klog's -v (KlogVerbosity)
+	mu             sync.RWMutex
 }
 
 // NewLogger creates a logger that uses klog directly
 func NewLogger(config *Config) (*Logger, error) {
-	logger := &Logger{}
+	logger := &Logger{loggerState: &loggerState{jsonComponents: make(map[string]bool)}}
+	for _, component := range defaultJSONComponents {
+		logger.jsonComponents[component] = true
+	}
+	for _, component := range config.JsonComponents {
+		logger.jsonComponents[component] = true
+	}
+	if config.LogDedupWindowSec > 0 {
+		logger.errorDedup = newLogDeduplicator(time.Duration(config.LogDedupWindowSec)*time.Second, func(line string) {
+			klog.Error(line)
+		})
+	}
 	
 	// Initialize klog flags only once globally
 	klogInitOnce.Do(func() {
 		klog.InitFlags(nil)
 	})
 	
-	// Configure klog verbosity based on log level
-	// This ensures debug messages are only shown when log level is debug
-	if config.LogLevel == "debug" {
-		flag.Set("v", "1") // Enable klog verbosity level 1 for debug messages
-	} else {
-		flag.Set("v", "0") // Disable debug verbosity for non-debug levels
+	// Faro's debug messages follow LogLevel; klog's -v (which also drives client-go's own
+	// V-level logging) follows it too unless KlogVerbosity is set, even to 0
+	logger.debugEnabled = config.LogLevel == "debug"
+	verbosity := 0
+	if logger.debugEnabled {
+		verbosity = 1
 	}
+	if config.KlogVerbosity != nil {
+		verbosity = *config.KlogVerbosity
+	}
+	flag.Set("v", strconv.Itoa(verbosity))
 	
 	// Parse flags to make the verbosity setting take effect
 	flag.Parse()
@@ -46,7 +94,7 @@ func NewLogger(config *Config) (*Logger, error) {
 	if logDir != "" {
 		// Ensure log directory exists
 		if err := os.MkdirAll(logDir, 0755); err != nil {
-			return nil, fmt.Errorf("failed to create log directory: %v", err)
+			return nil, fmt.Errorf("failed to create log directory %s: %w", logDir, err)
 		}
 
 		// Create log file with timestamp
@@ -56,7 +104,7 @@ func NewLogger(config *Config) (*Logger, error) {
 		// Create log file
 		logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create log file: %v", err)
+			return nil, fmt.Errorf("failed to create log file in %s: %w", logDir, err)
 		}
 		
 		// Create a MultiWriter to write to both stderr and file
@@ -73,21 +121,52 @@ func NewLogger(config *Config) (*Logger, error) {
 		// Handle JSON export separately if requested
 		if config.JsonExport {
 			jsonPath := fmt.Sprintf("%s/events-%s.json", logDir, timestamp)
-			jsonFile, err := os.OpenFile(jsonPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			logger.jsonIndexInterval = time.Duration(config.JsonIndexIntervalMs) * time.Millisecond
+			jsonExport, err := openJSONExportFile(jsonPath, logger.jsonIndexInterval)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create JSON log file: %v", err)
+				return nil, err
 			}
 			
-			logger.jsonFile = jsonFile
+			logger.jsonExport = jsonExport
+			logger.jsonTimestamp = timestamp
+			logger.jsonPartitionBy = config.JsonPartitionBy
+			logger.jsonPartitions = make(map[string]*jsonExportFile)
+			logger.jsonRotateSize = int64(config.JsonRotateSizeMB) * 1024 * 1024
+			logger.jsonCompress = config.JsonCompressRotated
 			
 			// Log JSON file path to stdout for test identification
 			fmt.Printf("FARO_JSON_FILE: %s\n", jsonPath)
 		}
+
+		// Faro's own error records go to a separate stream so alerting can tail them
+		if config.JsonErrorExport {
+			errorPath := fmt.Sprintf("%s/errors-%s.json", logDir, timestamp)
+			errorFile, err := os.OpenFile(errorPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create JSON error file in %s: %w", logDir, err)
+			}
+			logger.errorFile = errorFile
+			fmt.Printf("FARO_ERROR_FILE: %s\n", errorPath)
+		}
 	}
 	
 	return logger, nil
 }
 
+// WithPrefix returns a logger writing to the same outputs that tags every line with prefix,
+// e.g. "[cluster-2l1k7] [controller] ...", so controllers sharing a logger can be told apart
+func (l *Logger) WithPrefix(prefix string) *Logger {
+	return &Logger{loggerState: l.loggerState, prefix: prefix}
+}
+
+// tag returns the bracketed component a log line starts with, after the prefix if set
+func (l *Logger) tag(component string) string {
+	if l.prefix == "" {
+		return "[" + component + "]"
+	}
+	return "[" + l.prefix + "] [" + component + "]"
+}
+
 // SetConsoleEnabled enables or disables console output
 func (l *Logger) SetConsoleEnabled(enabled bool) {
 	// For klog, we can redirect to /dev/null to disable console
@@ -101,7 +180,7 @@ func (l *Logger) SetConsoleEnabled(enabled bool) {
 // LogJSON writes JSON events to the JSON file if configured
 func (l *Logger) LogJSON(component, message string) {
 	// Only handle messages from components that generate JSON events
-	if component != "cluster-handler" && component != "controller" {
+	if !l.jsonComponents[component] {
 		return
 	}
 	
@@ -112,22 +191,56 @@ func (l *Logger) LogJSON(component, message string) {
 		return
 	}
 	
-	if l.jsonFile != nil {
+	if l.jsonExport != nil {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 		
+		target, err := l.jsonExportTarget(message)
+		if err != nil {
+			klog.Warningf("[logger] %v", err)
+			target = l.jsonExport
+		}
+		
 		// Write pure JSON (one line per event)
-		l.jsonFile.WriteString(message + "\n")
-		l.jsonFile.Sync() // Ensure immediate write
+		target.write(message)
+		
+		if l.jsonRotateSize > 0 && target.offset >= l.jsonRotateSize {
+			if err := l.rotateJSONLocked(target); err != nil {
+				klog.Warningf("[logger] %v", err)
+			}
+		}
+	}
+}
+
+// logErrorRecord writes an error record to the JSON error export if configured
+func (l *Logger) logErrorRecord(level, component, errorType, message string, fields map[string]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.errorFile == nil {
+		return
+	}
+
+	line, err := json.Marshal(ErrorRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     level,
+		Component: component,
+		Type:      errorType,
+		Message:   message,
+		Fields:    fields,
+		Source:    l.prefix,
+	})
+	if err != nil {
+		return
 	}
+	l.errorFile.Write(append(line, '\n'))
 }
 
 // Debug logs a debug message with proper D level formatting
 func (l *Logger) Debug(component, message string) {
-	logLine := fmt.Sprintf("[%s] %s", component, message)
+	logLine := l.tag(component) + " " + message
 	
-	// Only show debug messages if verbosity is enabled
-	if klog.V(1).Enabled() {
+	// Only show debug messages at debug log level
+	if l.debugEnabled {
 		// Manually format as debug message with D prefix instead of I
 		timestamp := time.Now().Format("0102 15:04:05.000000")
 		pid := os.Getpid()
@@ -143,41 +256,85 @@ func (l *Logger) Debug(component, message string) {
 
 // Info logs an info message
 func (l *Logger) Info(component, message string) {
-	logLine := fmt.Sprintf("[%s] %s", component, message)
+	logLine := l.tag(component) + " " + message
 	klog.Info(logLine)
 	l.LogJSON(component, message)
 }
 
 // Warning logs a warning message
 func (l *Logger) Warning(component, message string) {
-	logLine := fmt.Sprintf("[%s] %s", component, message)
+	logLine := l.tag(component) + " " + message
 	klog.Warning(logLine)
 	l.LogJSON(component, message)
 }
 
-// Error logs an error message
+// Error logs an error message. With LogDedupWindowSec set, identical errors are
+// logged once per window followed by a count of the suppressed repeats.
 func (l *Logger) Error(component, message string) {
-	logLine := fmt.Sprintf("[%s] %s", component, message)
-	klog.Error(logLine)
+	logLine := l.tag(component) + " " + message
+	if l.errorDedup != nil {
+		l.errorDedup.Log(l.tag(component), message, logLine)
+	} else {
+		klog.Error(logLine)
+	}
 	l.LogJSON(component, message)
+	l.logErrorRecord("error", component, "", message, nil)
+}
+
+// ErrorWithFields logs an error like Error and records it in the JSON error export with
+// its type and structured fields
+func (l *Logger) ErrorWithFields(component, errorType, message string, fields map[string]string) {
+	logLine := l.tag(component) + " " + message
+	if l.errorDedup != nil {
+		l.errorDedup.Log(l.tag(component), message, logLine)
+	} else {
+		klog.Error(logLine)
+	}
+	l.logErrorRecord("error", component, errorType, message, fields)
+}
+
+// WarningWithFields logs a warning like Warning and records it in the JSON error export,
+// for recoverable failures such as watch errors that alerting still wants to see
+func (l *Logger) WarningWithFields(component, errorType, message string, fields map[string]string) {
+	klog.Warning(l.tag(component) + " " + message)
+	l.logErrorRecord("warning", component, errorType, message, fields)
 }
 
 // Fatal logs a fatal message
 func (l *Logger) Fatal(component, message string) {
-	logLine := fmt.Sprintf("[%s] %s", component, message)
+	logLine := l.tag(component) + " " + message
 	klog.Fatal(logLine)
 }
 
-// Shutdown gracefully shuts down the logger
+// Shutdown gracefully shuts down the logger: JSON export and error files are fsynced and
+// closed, and rotated segments finish compressing. Call it after Controller.Stop, which
+// exports the events still queued, so the final events of a run (often the deletes an
+// audit cares about most) are on disk before the process exits.
 func (l *Logger) Shutdown() {
+	if l.errorDedup != nil {
+		l.errorDedup.Stop()
+	}
+	
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	
-	// Close JSON file if open
-	if l.jsonFile != nil {
-		l.jsonFile.Close()
-		l.jsonFile = nil
+	// Close JSON files if open
+	if l.jsonExport != nil {
+		l.jsonExport.close()
+		l.jsonExport = nil
 	}
+	for partition, f := range l.jsonPartitions {
+		f.close()
+		delete(l.jsonPartitions, partition)
+	}
+	if l.errorFile != nil {
+		l.errorFile.Sync()
+		l.errorFile.Close()
+		l.errorFile = nil
+	}
+	
+	// Let background compressions of rotated segments finish
+	l.archiveWG.Wait()
 	
 	klog.Flush()
 }
\ No newline at end of file
@@ -2,6 +2,8 @@ package faro
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
@@ -18,16 +21,33 @@ type MetricsCollector struct {
 	enabled       bool
 	server        *http.Server
 	registry      *prometheus.Registry
-	logger        Logger
+	logger        *Logger
 	mu            sync.RWMutex
 	
 	// Core metrics
 	informerCount         *prometheus.GaugeVec
 	gvrPerInformer        *prometheus.GaugeVec
 	eventsPerGVR          *prometheus.CounterVec
+	eventsSampled         *prometheus.CounterVec
+	eventsThrottled       *prometheus.CounterVec
+	eventsFiltered        *prometheus.CounterVec
 	informerSyncDuration  *prometheus.HistogramVec
 	trackedResources      *prometheus.GaugeVec
 	uidResolutionSuccess  *prometheus.CounterVec
+	uidCacheEvictions     *prometheus.CounterVec
+	tombstoneEvents       *prometheus.CounterVec
+	discoveredResources   prometheus.Gauge
+	discoveredGroups      prometheus.Gauge
+	apiServiceAvailable   *prometheus.GaugeVec
+	controllerPaused      prometheus.Gauge
+	handlerDuration       *prometheus.HistogramVec
+	workItemsDropped      *prometheus.CounterVec
+	deliveryDropped       *prometheus.CounterVec
+	concurrencyDropped    *prometheus.CounterVec
+	handlerTimeouts       *prometheus.CounterVec
+	configReloads         *prometheus.CounterVec
+	keyFuncErrors         *prometheus.CounterVec
+	configLastReload      prometheus.Gauge
 	
 	// Advanced metrics
 	cacheHitRate          *prometheus.GaugeVec
@@ -36,10 +56,18 @@ type MetricsCollector struct {
 	
 	// Internal tracking
 	startTime             time.Time
+	
+	// Source of the effective configuration served on /config
+	configSource          func() *Config
+	redactSelectors       bool
+	
+	// Probe checks backing /health and /ready (nil = always OK)
+	healthCheck           func() bool
+	readyCheck            func() bool
 }
 
 // NewMetricsCollector creates a new metrics collector
-func NewMetricsCollector(config MetricsConfig, logger Logger) *MetricsCollector {
+func NewMetricsCollector(config MetricsConfig, logger *Logger) *MetricsCollector {
 	if !config.Enabled {
 		return &MetricsCollector{enabled: false, logger: logger}
 	}
@@ -58,10 +86,11 @@ func NewMetricsCollector(config MetricsConfig, logger Logger) *MetricsCollector
 	registry := prometheus.NewRegistry()
 	
 	mc := &MetricsCollector{
-		enabled:   true,
-		registry:  registry,
-		logger:    logger,
-		startTime: time.Now(),
+		enabled:         true,
+		registry:        registry,
+		logger:          logger,
+		startTime:       time.Now(),
+		redactSelectors: config.RedactConfigSelectors,
 	}
 	
 	mc.initializeMetrics()
@@ -86,7 +115,7 @@ func (mc *MetricsCollector) initializeMetrics() {
 			Name: "faro_gvr_per_informer",
 			Help: "Number of GVRs tracked per informer",
 		},
-		[]string{"gvr", "namespace_scoped"},
+		[]string{"gvr", "namespace_scoped", "config_format"}, // config_format: namespace, resource
 	)
 	
 	mc.eventsPerGVR = prometheus.NewCounterVec(
@@ -97,6 +126,62 @@ func (mc *MetricsCollector) initializeMetrics() {
 		[]string{"gvr", "event_type"}, // Removed namespace to reduce cardinality
 	)
 	
+	mc.eventsSampled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_events_sampled_total",
+			Help: "Total number of events dropped by per-resource sampling",
+		},
+		[]string{"gvr"},
+	)
+	
+	mc.eventsThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_events_throttled_total",
+			Help: "Total number of events dropped by per-resource max_events_per_sec",
+		},
+		[]string{"gvr"},
+	)
+	
+	mc.eventsFiltered = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_events_filtered_total",
+			Help: "Total number of events dropped by client-side filtering",
+		},
+		[]string{"gvr", "reason"},
+	)
+	
+	mc.workItemsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_workqueue_dropped_total",
+			Help: "Total number of work items dropped after exhausting their retries",
+		},
+		[]string{"gvr", "event_type"},
+	)
+	
+	mc.deliveryDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_event_delivery_dropped_total",
+			Help: "Total number of events dropped because a handler's delivery queue was full",
+		},
+		[]string{"sink"},
+	)
+	
+	mc.concurrencyDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_handler_concurrency_dropped_total",
+			Help: "Total number of events dropped because all max_handler_concurrency slots were busy",
+		},
+		[]string{"handler"},
+	)
+	
+	mc.handlerTimeouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_event_handler_timeouts_total",
+			Help: "Total number of ContextEventHandler calls whose context was cancelled by handler_timeout_sec",
+		},
+		[]string{"handler"},
+	)
+	
 	mc.informerSyncDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "faro_informer_sync_duration_seconds",
@@ -122,6 +207,83 @@ func (mc *MetricsCollector) initializeMetrics() {
 		[]string{"gvr", "status"}, // success, unknown, cache_miss
 	)
 	
+	mc.uidCacheEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_uid_cache_evictions_total",
+			Help: "UID cache entries evicted due to the UIDCacheMaxEntries cap",
+		},
+		[]string{"gvr"},
+	)
+	
+	mc.tombstoneEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_tombstone_events_total",
+			Help: "Deletes delivered as tombstones (DeletedFinalStateUnknown) by outcome",
+		},
+		[]string{"gvr", "result"}, // unstructured, reconstructed, dropped
+	)
+	
+	mc.discoveredResources = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "faro_discovered_resources_total",
+			Help: "Number of API resources (GVRs) found by discovery, including runtime CRDs",
+		},
+	)
+	
+	mc.discoveredGroups = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "faro_discovered_groups_total",
+			Help: "Number of API groups with discovered resources (core group included)",
+		},
+	)
+	
+	mc.apiServiceAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "faro_apiservice_available",
+			Help: "1 when every version of an API group answered discovery, 0 while one is unavailable (e.g. a down aggregated API)",
+		},
+		[]string{"group"},
+	)
+	
+	mc.controllerPaused = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "faro_controller_paused",
+			Help: "1 while event delivery is paused with Controller.Pause, 0 otherwise",
+		},
+	)
+	
+	mc.handlerDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "faro_event_handler_duration_seconds",
+			Help:    "Time spent in each event handler's OnMatched (or OnBatch) call",
+			Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1.0, 5.0},
+		},
+		[]string{"handler"}, // Name() of the handler, or handler-<index>/batch-handler-<index>
+	)
+	
+	mc.keyFuncErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_key_func_errors_total",
+			Help: "Events whose object had no namespace/name key and were queued by UID instead (or dropped without a UID)",
+		},
+		[]string{"gvr"},
+	)
+	
+	mc.configReloads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "faro_config_reloads_total",
+			Help: "Configuration reloads applied with Controller.Reload",
+		},
+		[]string{"result"}, // success, error
+	)
+	
+	mc.configLastReload = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "faro_config_last_reload_timestamp",
+			Help: "Unix timestamp of the last successful configuration reload",
+		},
+	)
+	
 	// Advanced metrics
 	mc.cacheHitRate = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -144,7 +306,7 @@ func (mc *MetricsCollector) initializeMetrics() {
 			Name: "faro_informer_health",
 			Help: "Informer health status (1=healthy, 0=unhealthy)",
 		},
-		[]string{"gvr", "status"}, // healthy, sync_failed, stale_events - limited enum values
+		[]string{"gvr", "status"}, // healthy, sync_failed, stale_events, circuit_open - limited enum values
 	)
 	
 	// Register all metrics
@@ -152,9 +314,26 @@ func (mc *MetricsCollector) initializeMetrics() {
 		mc.informerCount,
 		mc.gvrPerInformer,
 		mc.eventsPerGVR,
+		mc.eventsSampled,
+		mc.eventsThrottled,
+		mc.eventsFiltered,
+		mc.workItemsDropped,
+		mc.deliveryDropped,
+		mc.concurrencyDropped,
+		mc.handlerTimeouts,
 		mc.informerSyncDuration,
 		mc.trackedResources,
 		mc.uidResolutionSuccess,
+		mc.uidCacheEvictions,
+		mc.tombstoneEvents,
+		mc.discoveredResources,
+		mc.discoveredGroups,
+		mc.apiServiceAvailable,
+		mc.controllerPaused,
+		mc.handlerDuration,
+		mc.keyFuncErrors,
+		mc.configReloads,
+		mc.configLastReload,
 		mc.cacheHitRate,
 		mc.informerLastEventTime,
 		mc.informerHealth,
@@ -168,9 +347,11 @@ func (mc *MetricsCollector) initializeMetrics() {
 // startServer starts the HTTP metrics server
 func (mc *MetricsCollector) startServer(config MetricsConfig) {
 	mux := http.NewServeMux()
-	mux.Handle(config.Path, promhttp.HandlerFor(mc.registry, promhttp.HandlerOpts{}))
-	mux.HandleFunc("/health", mc.healthHandler)
+	// OpenMetrics is negotiated via the Accept header; only that format carries exemplars
+	mux.Handle(config.Path, requireBasicAuth(config, promhttp.HandlerFor(mc.registry, promhttp.HandlerOpts{EnableOpenMetrics: true})))
+	mux.HandleFunc("/health", mc.healthHandler) // Probes stay unauthenticated
 	mux.HandleFunc("/ready", mc.readinessHandler)
+	mux.Handle("/config", requireBasicAuth(config, http.HandlerFunc(mc.configHandler)))
 	
 	addr := fmt.Sprintf("%s:%d", config.BindAddr, config.Port)
 	mc.server = &http.Server{
@@ -179,24 +360,144 @@ func (mc *MetricsCollector) startServer(config MetricsConfig) {
 	}
 	
 	go func() {
-		mc.logger.Info("metrics", fmt.Sprintf("Starting metrics server on %s%s", addr, config.Path))
-		if err := mc.server.ListenAndServe(); err != http.ErrServerClosed {
+		var err error
+		if config.TLSCertFile != "" {
+			mc.logger.Info("metrics", fmt.Sprintf("Starting metrics server on https://%s%s", addr, config.Path))
+			err = mc.server.ListenAndServeTLS(config.TLSCertFile, config.TLSKeyFile)
+		} else {
+			mc.logger.Info("metrics", fmt.Sprintf("Starting metrics server on %s%s", addr, config.Path))
+			err = mc.server.ListenAndServe()
+		}
+		if err != http.ErrServerClosed {
 			mc.logger.Error("metrics", fmt.Sprintf("Metrics server error: %v", err))
 		}
 	}()
 }
 
+// requireBasicAuth wraps handler with HTTP basic auth when BasicAuthUser is configured
+func requireBasicAuth(config MetricsConfig, handler http.Handler) http.Handler {
+	if config.BasicAuthUser == "" {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		userMatches := subtle.ConstantTimeCompare([]byte(user), []byte(config.BasicAuthUser)) == 1
+		passwordMatches := subtle.ConstantTimeCompare([]byte(password), []byte(config.BasicAuthPassword)) == 1
+		if !ok || !userMatches || !passwordMatches {
+			w.Header().Set("WWW-Authenticate", `Basic realm="faro-metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
 // Health and readiness handlers
 func (mc *MetricsCollector) healthHandler(w http.ResponseWriter, r *http.Request) {
+	mc.mu.RLock()
+	check := mc.healthCheck
+	mc.mu.RUnlock()
+	
+	if check != nil && !check() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("Unhealthy"))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("OK"))
 }
 
 func (mc *MetricsCollector) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	mc.mu.RLock()
+	check := mc.readyCheck
+	mc.mu.RUnlock()
+	
+	if check != nil && !check() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("Not ready"))
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("Ready"))
 }
 
+// SetProbeChecks makes /health and /ready return 503 while the given checks report false
+func (mc *MetricsCollector) SetProbeChecks(healthy, ready func() bool) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.healthCheck = healthy
+	mc.readyCheck = ready
+}
+
+// configHandler serves the normalized configuration the informers were derived from
+func (mc *MetricsCollector) configHandler(w http.ResponseWriter, r *http.Request) {
+	mc.mu.RLock()
+	configSource := mc.configSource
+	mc.mu.RUnlock()
+	
+	var config *Config
+	if configSource != nil {
+		config = configSource()
+	}
+	if config == nil {
+		http.Error(w, "configuration not available", http.StatusServiceUnavailable)
+		return
+	}
+	
+	normalized, err := config.Normalize()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	
+	if mc.redactSelectors {
+		for _, configs := range normalized {
+			for i := range configs {
+				if configs[i].LabelSelector != "" {
+					configs[i].LabelSelector = "REDACTED"
+				}
+				if len(configs[i].LabelSelectors) > 0 {
+					redacted := make([]string, len(configs[i].LabelSelectors)) // Don't write through to the live config
+					for j := range redacted {
+						redacted[j] = "REDACTED"
+					}
+					configs[i].LabelSelectors = redacted
+				}
+			}
+		}
+	}
+	
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(normalized); err != nil {
+		mc.logger.Warning("metrics", fmt.Sprintf("Failed to encode /config response: %v", err))
+	}
+}
+
+// SetConfig sets the configuration exposed on the /config endpoint
+func (mc *MetricsCollector) SetConfig(config *Config) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.setConfigSource(func() *Config { return config })
+}
+
+// setConfigSource sets the function /config reads the configuration from on every request, so
+// the controller can serve a snapshot rather than the config it appends resources to
+func (mc *MetricsCollector) setConfigSource(source func() *Config) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.configSource = source
+}
+
 // Shutdown gracefully shuts down the metrics server
 func (mc *MetricsCollector) Shutdown(ctx context.Context) error {
 	if !mc.enabled || mc.server == nil {
@@ -209,14 +510,15 @@ func (mc *MetricsCollector) Shutdown(ctx context.Context) error {
 
 // === INFORMER LIFECYCLE HOOKS ===
 
-// OnInformerCreated is called when a new informer is created
-func (mc *MetricsCollector) OnInformerCreated(gvr string, scope apiextensionsv1.ResourceScope) {
+// OnInformerCreated is called when a new informer is created. configFormat is the
+// ConfigFormat* constant of the configuration entries the informer serves.
+func (mc *MetricsCollector) OnInformerCreated(gvr string, scope apiextensionsv1.ResourceScope, configFormat string) {
 	if !mc.enabled {
 		return
 	}
 	
 	mc.informerCount.WithLabelValues("syncing").Inc()
-	mc.gvrPerInformer.WithLabelValues(gvr, strconv.FormatBool(scope == apiextensionsv1.NamespaceScoped)).Set(1)
+	mc.gvrPerInformer.WithLabelValues(gvr, strconv.FormatBool(scope == apiextensionsv1.NamespaceScoped), configFormat).Set(1)
 	mc.informerHealth.WithLabelValues(gvr, "healthy").Set(1) // Use controlled enum value
 }
 
@@ -259,6 +561,61 @@ func (mc *MetricsCollector) OnEventProcessed(gvr, eventType, namespace string) {
 	mc.informerLastEventTime.WithLabelValues(gvr).Set(float64(time.Now().Unix()))
 }
 
+// OnEventSampled is called when an event is dropped by sampling
+func (mc *MetricsCollector) OnEventSampled(gvr string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.eventsSampled.WithLabelValues(gvr).Inc()
+}
+
+// OnEventFiltered is called when the controller drops an event because no config matched it
+func (mc *MetricsCollector) OnEventFiltered(gvr, reason string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.eventsFiltered.WithLabelValues(gvr, reason).Inc()
+}
+
+// OnWorkItemDropped is called when a work item is dropped after MaxReconcileRetries
+func (mc *MetricsCollector) OnWorkItemDropped(gvr, eventType string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.workItemsDropped.WithLabelValues(gvr, eventType).Inc()
+}
+
+// OnEventDeliveryDropped is called when an event is dropped because sink's queue is full
+func (mc *MetricsCollector) OnEventDeliveryDropped(sink string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.deliveryDropped.WithLabelValues(sink).Inc()
+}
+
+// OnHandlerConcurrencyDropped is called when an event is dropped for handler because all
+// MaxHandlerConcurrency slots were busy
+func (mc *MetricsCollector) OnHandlerConcurrencyDropped(handler string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.concurrencyDropped.WithLabelValues(handler).Inc()
+}
+
+// OnHandlerTimeout is called when a ContextEventHandler call exceeded HandlerTimeoutSec
+func (mc *MetricsCollector) OnHandlerTimeout(handler string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.handlerTimeouts.WithLabelValues(handler).Inc()
+}
+
 // OnResourceTracked is called when a resource is added to UID cache
 func (mc *MetricsCollector) OnResourceTracked(gvr, namespace string, delta int64) {
 	if !mc.enabled {
@@ -282,6 +639,15 @@ func (mc *MetricsCollector) OnUIDResolution(gvr, status string) {
 	mc.uidResolutionSuccess.WithLabelValues(gvr, status).Inc()
 }
 
+// OnUIDCacheEviction is called when a UID cache entry is evicted by the size cap
+func (mc *MetricsCollector) OnUIDCacheEviction(gvr string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.uidCacheEvictions.WithLabelValues(gvr).Inc()
+}
+
 
 // UpdateCacheHitRate updates the cache hit rate for a GVR
 func (mc *MetricsCollector) UpdateCacheHitRate(gvr string, hitRate float64) {
@@ -292,6 +658,116 @@ func (mc *MetricsCollector) UpdateCacheHitRate(gvr string, hitRate float64) {
 	mc.cacheHitRate.WithLabelValues(gvr).Set(hitRate)
 }
 
+// OnInformerCircuitOpen is called when an informer is stopped after too many restarts
+func (mc *MetricsCollector) OnInformerCircuitOpen(gvr string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.informerHealth.WithLabelValues(gvr, "healthy").Set(0)
+	mc.informerHealth.WithLabelValues(gvr, "circuit_open").Set(0) // Controlled enum value
+}
+
+// OnTombstoneEvent is called for every delete delivered as a tombstone
+func (mc *MetricsCollector) OnTombstoneEvent(gvr, result string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.tombstoneEvents.WithLabelValues(gvr, result).Inc()
+}
+
+// SetDiscoveredResources records the current size of the discovered API surface
+func (mc *MetricsCollector) SetDiscoveredResources(resourceCount, groupCount int) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.discoveredResources.Set(float64(resourceCount))
+	mc.discoveredGroups.Set(float64(groupCount))
+}
+
+// SetAPIServiceAvailable records whether an API group's versions all answered discovery
+func (mc *MetricsCollector) SetAPIServiceAvailable(group string, available bool) {
+	if !mc.enabled {
+		return
+	}
+	
+	value := 0.0
+	if available {
+		value = 1
+	}
+	mc.apiServiceAvailable.WithLabelValues(group).Set(value)
+}
+
+// APIServiceAvailable returns faro_apiservice_available for a group (0 when metrics are disabled)
+func (mc *MetricsCollector) APIServiceAvailable(group string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.apiServiceAvailable, map[string]string{"group": group})
+}
+
+// SetControllerPaused records whether event delivery is paused
+func (mc *MetricsCollector) SetControllerPaused(paused bool) {
+	if !mc.enabled {
+		return
+	}
+	
+	if paused {
+		mc.controllerPaused.Set(1)
+	} else {
+		mc.controllerPaused.Set(0)
+	}
+}
+
+// OnHandlerCompleted records how long an event handler took for one event or batch
+func (mc *MetricsCollector) OnHandlerCompleted(handler string, duration time.Duration) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.handlerDuration.WithLabelValues(handler).Observe(duration.Seconds())
+}
+
+// OnHandlerCompletedWithTrace records how long an event handler took for one event and, when
+// traceID is set, attaches it to the sample as a trace_id exemplar (Controller.SetTraceIDFunc)
+func (mc *MetricsCollector) OnHandlerCompletedWithTrace(handler string, duration time.Duration, traceID string) {
+	if !mc.enabled {
+		return
+	}
+	if traceID == "" {
+		mc.handlerDuration.WithLabelValues(handler).Observe(duration.Seconds())
+		return
+	}
+	
+	observer := mc.handlerDuration.WithLabelValues(handler).(prometheus.ExemplarObserver)
+	observer.ObserveWithExemplar(duration.Seconds(), prometheus.Labels{"trace_id": traceID})
+}
+
+// OnKeyFuncError is called when an event's object key can't be computed
+func (mc *MetricsCollector) OnKeyFuncError(gvr string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.keyFuncErrors.WithLabelValues(gvr).Inc()
+}
+
+// OnConfigReload records the result of a Controller.Reload call
+func (mc *MetricsCollector) OnConfigReload(err error) {
+	if !mc.enabled {
+		return
+	}
+	
+	if err != nil {
+		mc.configReloads.WithLabelValues("error").Inc()
+		return
+	}
+	mc.configReloads.WithLabelValues("success").Inc()
+	mc.configLastReload.SetToCurrentTime()
+}
+
 // SetInformerStale marks an informer as having stale events
 func (mc *MetricsCollector) SetInformerStale(gvr string, isStale bool) {
 	if !mc.enabled {
@@ -312,6 +788,123 @@ func (mc *MetricsCollector) IsEnabled() bool {
 	return mc.enabled
 }
 
+// EventCount returns faro_events_total for a GVR and event type (0 when metrics are disabled)
+func (mc *MetricsCollector) EventCount(gvr, eventType string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.eventsPerGVR, map[string]string{"gvr": gvr, "event_type": eventType})
+}
+
+// FilteredEventCount returns faro_events_filtered_total for a GVR and reason (0 when metrics are disabled)
+func (mc *MetricsCollector) FilteredEventCount(gvr, reason string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.eventsFiltered, map[string]string{"gvr": gvr, "reason": reason})
+}
+
+// OnEventThrottled is called when an event is dropped by max_events_per_sec
+func (mc *MetricsCollector) OnEventThrottled(gvr string) {
+	if !mc.enabled {
+		return
+	}
+	
+	mc.eventsThrottled.WithLabelValues(gvr).Inc()
+}
+
+// ThrottledEventCount returns faro_events_throttled_total for a GVR (0 when metrics are disabled)
+func (mc *MetricsCollector) ThrottledEventCount(gvr string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.eventsThrottled, map[string]string{"gvr": gvr})
+}
+
+// SampledEventCount returns faro_events_sampled_total for a GVR (0 when metrics are disabled)
+func (mc *MetricsCollector) SampledEventCount(gvr string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.eventsSampled, map[string]string{"gvr": gvr})
+}
+
+// DroppedWorkItemCount returns faro_workqueue_dropped_total for a GVR, summed over event types (0 when metrics are disabled)
+func (mc *MetricsCollector) DroppedWorkItemCount(gvr string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.workItemsDropped, map[string]string{"gvr": gvr})
+}
+
+// DroppedDeliveryCount returns faro_event_delivery_dropped_total for a sink (0 when metrics are disabled)
+func (mc *MetricsCollector) DroppedDeliveryCount(sink string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.deliveryDropped, map[string]string{"sink": sink})
+}
+
+// HandlerConcurrencyDroppedCount returns faro_handler_concurrency_dropped_total for a handler (0 when metrics are disabled)
+func (mc *MetricsCollector) HandlerConcurrencyDroppedCount(handler string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.concurrencyDropped, map[string]string{"handler": handler})
+}
+
+// HandlerTimeoutCount returns faro_event_handler_timeouts_total for a handler (0 when metrics are disabled)
+func (mc *MetricsCollector) HandlerTimeoutCount(handler string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.handlerTimeouts, map[string]string{"handler": handler})
+}
+
+// KeyFuncErrorCount returns faro_key_func_errors_total for a GVR (0 when metrics are disabled)
+func (mc *MetricsCollector) KeyFuncErrorCount(gvr string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.keyFuncErrors, map[string]string{"gvr": gvr})
+}
+
+// ConfigReloadCount returns faro_config_reloads_total for a result (0 when metrics are disabled)
+func (mc *MetricsCollector) ConfigReloadCount(result string) float64 {
+	if !mc.enabled {
+		return 0
+	}
+	return counterValue(mc.configReloads, map[string]string{"result": result})
+}
+
+// counterValue sums the collector's counters (or gauges) whose labels include all of labels. Unlike
+// GetMetricWithLabelValues it never creates a series that would then be exported.
+func counterValue(collector prometheus.Collector, labels map[string]string) float64 {
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		collector.Collect(metrics)
+		close(metrics)
+	}()
+
+	var total float64
+	for metric := range metrics {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			continue
+		}
+		matched := 0
+		for _, pair := range m.GetLabel() {
+			if value, wanted := labels[pair.GetName()]; wanted && value == pair.GetValue() {
+				matched++
+			}
+		}
+		if matched == len(labels) {
+			total += m.GetCounter().GetValue() + m.GetGauge().GetValue()
+		}
+	}
+	return total
+}
+
 // GetUptime returns the uptime of the metrics collector
 func (mc *MetricsCollector) GetUptime() time.Duration {
 	return time.Since(mc.startTime)
@@ -330,8 +923,25 @@ func (mc *MetricsCollector) ResetMetrics() {
 	mc.informerCount.Reset()
 	mc.gvrPerInformer.Reset()
 	mc.eventsPerGVR.Reset()
+	mc.eventsSampled.Reset()
+	mc.eventsThrottled.Reset()
+	mc.eventsFiltered.Reset()
+	mc.workItemsDropped.Reset()
+	mc.deliveryDropped.Reset()
+	mc.concurrencyDropped.Reset()
+	mc.handlerTimeouts.Reset()
 	mc.trackedResources.Reset()
 	mc.uidResolutionSuccess.Reset()
+	mc.uidCacheEvictions.Reset()
+	mc.tombstoneEvents.Reset()
+	mc.discoveredResources.Set(0)
+	mc.discoveredGroups.Set(0)
+	mc.apiServiceAvailable.Reset()
+	mc.controllerPaused.Set(0)
+	mc.handlerDuration.Reset()
+	mc.keyFuncErrors.Reset()
+	mc.configReloads.Reset()
+	mc.configLastReload.Set(0)
 	mc.cacheHitRate.Reset()
 	mc.informerLastEventTime.Reset()
 	mc.informerHealth.Reset()
@@ -0,0 +1,105 @@
+package faro
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// maxOwnerDepth bounds owner chain walks (guards against reference cycles)
+const maxOwnerDepth = 10
+
+// EventOwner is one link of an object's owner chain in a JSON event
+type EventOwner struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	UID        string `json:"uid"`
+}
+
+// OwnerResolver walks metadata.ownerReferences through the controller's informer caches,
+// e.g. Pod -> ReplicaSet -> Deployment. It never calls the API server: the walk stops at
+// the first owner that isn't cached, which is still recorded from the child's reference.
+type OwnerResolver struct {
+	controller *Controller
+}
+
+// NewOwnerResolver creates an owner resolver backed by the controller's informer caches
+func NewOwnerResolver(controller *Controller) *OwnerResolver {
+	return &OwnerResolver{controller: controller}
+}
+
+// Resolve returns the owner chain of obj, nearest owner first. At each level the
+// controller owner reference is followed, falling back to the first reference.
+func (r *OwnerResolver) Resolve(obj *unstructured.Unstructured) []EventOwner {
+	var owners []EventOwner
+	current := obj
+
+	for depth := 0; current != nil && depth < maxOwnerDepth; depth++ {
+		refs := current.GetOwnerReferences()
+		if len(refs) == 0 {
+			break
+		}
+
+		ref := refs[0]
+		for _, candidate := range refs {
+			if candidate.Controller != nil && *candidate.Controller {
+				ref = candidate
+				break
+			}
+		}
+
+		owners = append(owners, EventOwner{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			UID:        string(ref.UID),
+		})
+
+		owner := r.lookup(ref.APIVersion, ref.Kind, current.GetNamespace(), ref.Name)
+		if owner == nil || owner.GetUID() != ref.UID {
+			break // Not cached (or a different incarnation) - keep the reference only
+		}
+		current = owner
+	}
+
+	return owners
+}
+
+// lookup finds an owner object in the informer caches. Owners live in the child's
+// namespace unless the owner kind is cluster-scoped.
+func (r *OwnerResolver) lookup(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil
+	}
+
+	c := r.controller
+	gvrString, info, found := c.resourceForKind(gv.WithKind(kind))
+	if !found {
+		return nil
+	}
+	namespaced := info.Namespaced
+
+	key := name
+	listerKeys := []string{gvrString + "@"} // Cluster-scoped or all-namespaces informer
+	if namespaced {
+		key = namespace + "/" + name
+		listerKeys = append([]string{gvrString + "@" + namespace}, listerKeys...)
+	}
+
+	for _, listerKey := range listerKeys {
+		listerInterface, exists := c.listers.Load(listerKey)
+		if !exists {
+			continue
+		}
+		obj, err := listerInterface.(cache.GenericLister).Get(key)
+		if err != nil {
+			continue
+		}
+		if unstructuredObj, ok := obj.(*unstructured.Unstructured); ok {
+			return unstructuredObj
+		}
+	}
+	return nil
+}
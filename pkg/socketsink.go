@@ -0,0 +1,167 @@
+package faro
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultSocketSinkBufferSize = 1000
+	socketSinkMinBackoff        = 100 * time.Millisecond
+	socketSinkMaxBackoff        = 5 * time.Second
+)
+
+// UnixSocketSink is an EventHandler writing matched events as JSON lines (JSONEvent) to a Unix
+// domain socket, for co-located consumers such as sidecars. Events wait in a bounded buffer and
+// are written by one goroutine that reconnects with backoff when the consumer restarts; when the
+// buffer is full the event is dropped and counted (Dropped).
+type UnixSocketSink struct {
+	path    string
+	lines   chan []byte
+	dropped atomic.Int64
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// NewUnixSocketSink creates a sink for the socket at path buffering up to bufferSize events
+// (0 = 1000). The socket doesn't need to exist yet; the sink keeps trying to connect.
+func NewUnixSocketSink(path string, bufferSize int) *UnixSocketSink {
+	if bufferSize <= 0 {
+		bufferSize = defaultSocketSinkBufferSize
+	}
+	s := &UnixSocketSink{
+		path:  path,
+		lines: make(chan []byte, bufferSize),
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Name labels the sink's handler metrics
+func (s *UnixSocketSink) Name() string {
+	return "unix-socket:" + s.path
+}
+
+// ReadOnlyEvents reports that the sink never modifies event.Object
+func (s *UnixSocketSink) ReadOnlyEvents() bool {
+	return true
+}
+
+// OnMatched encodes the event and buffers it for the writer, dropping it when the buffer is full
+func (s *UnixSocketSink) OnMatched(event MatchedEvent) error {
+	record := JSONEvent{
+		Timestamp: event.Timestamp.UTC().Format(time.RFC3339),
+		EventType: event.EventType,
+		GVR:       event.GVR,
+		ConfigID:  event.Config.ConfigID,
+	}
+	if event.Object != nil {
+		record.Namespace = event.Object.GetNamespace()
+		record.Name = event.Object.GetName()
+		record.UID = string(event.Object.GetUID())
+		record.Labels = event.Object.GetLabels()
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode socket sink event: %w", err)
+	}
+
+	select {
+	case <-s.quit:
+		return fmt.Errorf("unix socket sink %s is closed", s.path)
+	default:
+	}
+	select {
+	case s.lines <- append(line, '\n'):
+	default:
+		s.dropped.Add(1)
+	}
+	return nil
+}
+
+// Dropped returns the number of events dropped because the buffer was full
+func (s *UnixSocketSink) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// run writes buffered lines, reconnecting whenever the connection fails. A line whose write
+// failed is retried on the next connection, so a consumer restart loses no buffered events.
+func (s *UnixSocketSink) run() {
+	defer close(s.done)
+
+	var conn net.Conn
+	var pending []byte
+	backoff := socketSinkMinBackoff
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for {
+		if pending == nil {
+			select {
+			case pending = <-s.lines:
+			case <-s.quit:
+				s.flush(conn)
+				return
+			}
+		}
+
+		if conn == nil {
+			var err error
+			conn, err = net.Dial("unix", s.path)
+			if err != nil {
+				conn = nil
+				select {
+				case <-time.After(backoff):
+				case <-s.quit:
+					return
+				}
+				backoff = min(backoff*2, socketSinkMaxBackoff)
+				continue
+			}
+			backoff = socketSinkMinBackoff
+		}
+
+		if _, err := conn.Write(pending); err != nil {
+			conn.Close()
+			conn = nil
+			continue
+		}
+		pending = nil
+	}
+}
+
+// flush writes the lines still buffered at Close, giving up at the first failure
+func (s *UnixSocketSink) flush(conn net.Conn) {
+	if conn == nil {
+		return
+	}
+	for {
+		select {
+		case line := <-s.lines:
+			if _, err := conn.Write(line); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// Close stops the writer after writing what is buffered to the current connection
+func (s *UnixSocketSink) Close() error {
+	select {
+	case <-s.quit:
+	default:
+		close(s.quit)
+	}
+	<-s.done
+	return nil
+}
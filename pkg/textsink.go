@@ -0,0 +1,85 @@
+package faro
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// TextSinkEvent is the data a TextSink layout template is rendered with
+type TextSinkEvent struct {
+	EventType string
+	GVR       string
+	Namespace string
+	Name      string
+	UID       string
+	Labels    map[string]string
+	Timestamp time.Time
+}
+
+// TextSink is an EventHandler writing one line per matched event to a file, rendered
+// from a text/template layout such as "{{.EventType}} {{.GVR}} {{.Namespace}}/{{.Name}}"
+type TextSink struct {
+	file     *os.File
+	template *template.Template
+	mu       sync.Mutex
+}
+
+// NewTextSink creates a sink appending rendered events to path. The layout is parsed and
+// test-rendered here so typos like {{.Nmae}} fail at construction rather than per event.
+func NewTextSink(path, layout string) (*TextSink, error) {
+	tmpl, err := template.New("text-sink").Option("missingkey=error").Parse(layout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid text sink layout: %w", err)
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, TextSinkEvent{}); err != nil {
+		return nil, fmt.Errorf("invalid text sink layout: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open text sink file: %w", err)
+	}
+
+	return &TextSink{file: file, template: tmpl}, nil
+}
+
+// OnMatched renders the event and appends it to the file as a single line
+func (s *TextSink) OnMatched(event MatchedEvent) error {
+	data := TextSinkEvent{
+		EventType: event.EventType,
+		GVR:       event.GVR,
+		Timestamp: event.Timestamp,
+	}
+	if event.Object != nil {
+		data.Namespace = event.Object.GetNamespace()
+		data.Name = event.Object.GetName()
+		data.UID = string(event.Object.GetUID())
+		data.Labels = event.Object.GetLabels()
+	}
+
+	var line bytes.Buffer
+	if err := s.template.Execute(&line, data); err != nil {
+		return fmt.Errorf("failed to render text sink line: %w", err)
+	}
+	if line.Len() == 0 || line.Bytes()[line.Len()-1] != '\n' {
+		line.WriteByte('\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(line.Bytes()); err != nil {
+		return fmt.Errorf("failed to write text sink line: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying file
+func (s *TextSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,43 @@
+package faro
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Tombstone outcomes reported by faro_tombstone_events_total
+const (
+	TombstoneResultUnstructured  = "unstructured"  // Tombstone carried the final object
+	TombstoneResultReconstructed = "reconstructed" // Object rebuilt from metadata or the cache key
+	TombstoneResultDropped       = "dropped"       // Nothing usable, the delete could not be processed
+)
+
+// DeletedObjectFromTombstone extracts the deleted object from a DeletedFinalStateUnknown
+// tombstone, which informers deliver when a delete was missed (e.g. during an API server
+// disconnect). When the inner object is not unstructured, a minimal object is rebuilt from
+// its metadata or, failing that, from the tombstone's namespace/name key so the DELETE can
+// still be processed. The result is one of the TombstoneResult constants.
+func DeletedObjectFromTombstone(tombstone cache.DeletedFinalStateUnknown) (*unstructured.Unstructured, string) {
+	if obj, ok := tombstone.Obj.(*unstructured.Unstructured); ok {
+		return obj, TombstoneResultUnstructured
+	}
+
+	reconstructed := &unstructured.Unstructured{}
+	if accessor, err := meta.Accessor(tombstone.Obj); err == nil && accessor.GetName() != "" {
+		reconstructed.SetNamespace(accessor.GetNamespace())
+		reconstructed.SetName(accessor.GetName())
+		reconstructed.SetUID(accessor.GetUID())
+		reconstructed.SetLabels(accessor.GetLabels())
+		reconstructed.SetAnnotations(accessor.GetAnnotations())
+		return reconstructed, TombstoneResultReconstructed
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(tombstone.Key)
+	if err != nil || name == "" {
+		return nil, TombstoneResultDropped
+	}
+	reconstructed.SetNamespace(namespace)
+	reconstructed.SetName(name)
+	return reconstructed, TombstoneResultReconstructed
+}
@@ -0,0 +1,44 @@
+package faro
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// TransformFunc trims an object before the informer caches it (Controller.SetTransform), so
+// GVRs with huge objects don't bloat memory. It may modify obj in place and return it. Fields
+// it removes are gone for everyone: handlers, JSON export, GetObject and snapshots.
+type TransformFunc func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+
+// NoTransform caches objects unchanged; it is what GVRs without a TransformFunc get
+func NoTransform(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return obj, nil
+}
+
+// DropFields returns a TransformFunc removing the given dotted field paths, e.g.
+// DropFields("data", "binaryData", "metadata.managedFields")
+func DropFields(paths ...string) TransformFunc {
+	fields := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		fields = append(fields, strings.Split(path, "."))
+	}
+	return func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		for _, field := range fields {
+			unstructured.RemoveNestedField(obj.Object, field...)
+		}
+		return obj, nil
+	}
+}
+
+// cacheTransform adapts a TransformFunc to the informer's cache.TransformFunc; anything
+// other than an unstructured object is passed through
+func cacheTransform(transform TransformFunc) func(interface{}) (interface{}, error) {
+	return func(obj interface{}) (interface{}, error) {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return obj, nil
+		}
+		return transform(u)
+	}
+}
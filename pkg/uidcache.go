@@ -0,0 +1,100 @@
+package faro
+
+import (
+	"container/list"
+	"sync"
+)
+
+// UIDCache maps resource keys to UIDs with optional least-recently-used eviction.
+// With maxEntries <= 0 the cache is unbounded (the historical behavior).
+//
+// Eviction trades memory for completeness: if an entry is evicted before the
+// object's DELETED event is processed, the event is handled per DeleteUIDPolicy -
+// dropped by default, exported with uid "unknown" under DeleteUIDPolicyUnknown.
+type UIDCache struct {
+	maxEntries int
+	onEvict    func(key string)
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // Front is most recently used
+}
+
+// uidCacheEntry is the value stored in each LRU list element
+type uidCacheEntry struct {
+	key string
+	uid string
+}
+
+// NewUIDCache creates a UID cache holding at most maxEntries keys (0 = unbounded).
+// onEvict, if non-nil, is called with the key of every entry evicted by the cap.
+func NewUIDCache(maxEntries int, onEvict func(key string)) *UIDCache {
+	return &UIDCache{
+		maxEntries: maxEntries,
+		onEvict:    onEvict,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Load returns the UID stored for key and marks it as recently used
+func (u *UIDCache) Load(key string) (string, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	element, exists := u.entries[key]
+	if !exists {
+		return "", false
+	}
+	u.order.MoveToFront(element)
+	return element.Value.(*uidCacheEntry).uid, true
+}
+
+// Store records the UID for key, evicting the least recently used entry when over the cap
+func (u *UIDCache) Store(key, uid string) {
+	u.mu.Lock()
+
+	if element, exists := u.entries[key]; exists {
+		element.Value.(*uidCacheEntry).uid = uid
+		u.order.MoveToFront(element)
+		u.mu.Unlock()
+		return
+	}
+
+	u.entries[key] = u.order.PushFront(&uidCacheEntry{key: key, uid: uid})
+
+	var evicted []string
+	for u.maxEntries > 0 && u.order.Len() > u.maxEntries {
+		oldest := u.order.Back()
+		entry := oldest.Value.(*uidCacheEntry)
+		u.order.Remove(oldest)
+		delete(u.entries, entry.key)
+		evicted = append(evicted, entry.key)
+	}
+	u.mu.Unlock()
+
+	// Notify outside the lock so callbacks can safely use the cache
+	if u.onEvict != nil {
+		for _, key := range evicted {
+			u.onEvict(key)
+		}
+	}
+}
+
+// Delete removes key from the cache
+func (u *UIDCache) Delete(key string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if element, exists := u.entries[key]; exists {
+		u.order.Remove(element)
+		delete(u.entries, key)
+	}
+}
+
+// Len returns the number of cached entries
+func (u *UIDCache) Len() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.order.Len()
+}
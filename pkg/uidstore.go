@@ -0,0 +1,69 @@
+package faro
+
+import (
+	"strings"
+	"sync"
+)
+
+// UIDStore persists object UIDs by resource key ("gvr/namespace/name" or "gvr/name") alongside
+// the in-memory UID caches. Set one with Controller.SetUIDStore to resolve the UID of DELETED
+// events for objects the current process never cached, e.g. after a restart.
+// Implementations must be safe for concurrent use.
+type UIDStore interface {
+	Get(key string) (uid string, found bool, err error)
+	Put(key, uid string) error
+	Delete(key string) error
+}
+
+// ListableUIDStore is a UIDStore that can enumerate its keys. When the controller's store
+// implements it, every informer compares the stored keys under its GVR and namespace with its
+// initial list once synced, and reports the missing objects as DELETED with their stored UID,
+// covering deletions that happened while Faro was not running.
+type ListableUIDStore interface {
+	UIDStore
+	Keys(prefix string) ([]string, error)
+}
+
+// MemoryUIDStore is an in-memory ListableUIDStore. It does not survive restarts on its own,
+// but can be shared between controllers or wrapped by a persistent implementation.
+type MemoryUIDStore struct {
+	uids sync.Map
+}
+
+// NewMemoryUIDStore creates an empty in-memory UID store
+func NewMemoryUIDStore() *MemoryUIDStore {
+	return &MemoryUIDStore{}
+}
+
+// Get returns the UID stored for key
+func (s *MemoryUIDStore) Get(key string) (string, bool, error) {
+	uid, found := s.uids.Load(key)
+	if !found {
+		return "", false, nil
+	}
+	return uid.(string), true, nil
+}
+
+// Put stores the UID for key
+func (s *MemoryUIDStore) Put(key, uid string) error {
+	s.uids.Store(key, uid)
+	return nil
+}
+
+// Delete removes key from the store
+func (s *MemoryUIDStore) Delete(key string) error {
+	s.uids.Delete(key)
+	return nil
+}
+
+// Keys returns every stored key starting with prefix
+func (s *MemoryUIDStore) Keys(prefix string) ([]string, error) {
+	var keys []string
+	s.uids.Range(func(key, _ interface{}) bool {
+		if k := key.(string); strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	return keys, nil
+}
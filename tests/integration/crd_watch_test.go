@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	faro "github.com/T0MASD/faro/pkg"
+	"github.com/T0MASD/faro/tests/testutils"
+)
+
+// TestWatchCRDsMonitorsRuntimeCRD installs a CRD after Faro started and verifies
+// that its custom resources are monitored when WatchCRDs is enabled
+func TestWatchCRDsMonitorsRuntimeCRD(t *testing.T) {
+	logDir := "./logs/TestWatchCRDsMonitorsRuntimeCRD"
+	testutils.EnsureLogDir(t, logDir)
+
+	k8sClient, _ := testutils.CreateKubernetesClients(t)
+
+	crdManifest := "manifests/crd-watch-test-crd.yaml"
+	crManifest := "manifests/crd-watch-test-cr.yaml"
+	cleanup := func() {
+		t.Log("🧹 Cleaning up test resources...")
+		testutils.DeleteManifestWithWait(t, crManifest, 10*time.Second)
+		testutils.DeleteManifestWithWait(t, crdManifest, 10*time.Second)
+		testutils.DeleteNamespace(t, k8sClient, "faro-test-crd")
+	}
+	cleanup()
+	defer cleanup()
+
+	if err := exec.Command("kubectl", "create", "namespace", "faro-test-crd").Run(); err != nil {
+		t.Fatalf("Failed to create namespace faro-test-crd: %v", err)
+	}
+
+	// PHASE 1: start Faro before the CRD exists
+	config := &faro.Config{
+		OutputDir:  logDir,
+		LogLevel:   "debug",
+		JsonExport: true,
+		WatchCRDs:  true,
+		Resources: []faro.ResourceConfig{
+			{
+				GVR:            "faro-test.example.com/v1/widgets",
+				Scope:          faro.NamespaceScope,
+				NamespaceNames: []string{"faro-test-crd"},
+			},
+		},
+	}
+
+	faroClient, err := faro.NewKubernetesClient()
+	if err != nil {
+		t.Fatalf("Failed to create Faro Kubernetes client: %v", err)
+	}
+	logger, err := faro.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create Faro logger: %v", err)
+	}
+	defer logger.Shutdown()
+
+	controller := faro.NewController(faroClient, logger, config)
+	discovered := make(chan string, 1)
+	controller.SetCRDDiscoveredCallback(func(crdName, gvrString string) {
+		discovered <- gvrString
+	})
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Failed to start Faro controller: %v", err)
+	}
+	defer controller.Stop()
+
+	// PHASE 2: install the CRD and wait for Faro to pick it up
+	if err := testutils.ApplyManifestWithWait(t, crdManifest, 30*time.Second); err != nil {
+		t.Fatalf("Failed to apply CRD: %v", err)
+	}
+	select {
+	case gvrString := <-discovered:
+		t.Logf("✅ Runtime CRD discovered: %s", gvrString)
+	case <-time.After(60 * time.Second):
+		t.Fatal("CRD was not discovered within timeout")
+	}
+
+	// PHASE 3: create a custom resource and verify it is exported
+	if err := exec.Command("kubectl", "wait", "--for=condition=Established", "crd/widgets.faro-test.example.com", "--timeout=30s").Run(); err != nil {
+		t.Fatalf("CRD did not become established: %v", err)
+	}
+	if err := testutils.ApplyManifestWithWait(t, crManifest, 30*time.Second); err != nil {
+		t.Fatalf("Failed to apply custom resource: %v", err)
+	}
+
+	// Wait for events to be processed
+	time.Sleep(5 * time.Second)
+
+	for _, event := range testutils.ReadJSONEvents(t, logDir) {
+		if event.GVR == "faro-test.example.com/v1/widgets" && event.Name == "test-widget-1" && event.EventType == "ADDED" {
+			t.Log("✅ Custom resource of runtime CRD captured")
+			return
+		}
+	}
+	t.Error("❌ ADDED event for test-widget-1 not found in JSON export")
+}
@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	faro "github.com/T0MASD/faro/pkg"
+	"github.com/T0MASD/faro/tests/testutils"
+)
+
+// TestNamespaceLabelSelectorWatchesLabeledNamespace labels a namespace after Faro started and
+// verifies that its ConfigMaps are monitored through namespace_label_selector
+func TestNamespaceLabelSelectorWatchesLabeledNamespace(t *testing.T) {
+	logDir := "./logs/TestNamespaceLabelSelectorWatchesLabeledNamespace"
+	testutils.EnsureLogDir(t, logDir)
+
+	k8sClient, _ := testutils.CreateKubernetesClients(t)
+
+	namespace := "faro-test-ns-selector"
+	cleanup := func() {
+		t.Log("🧹 Cleaning up test resources...")
+		testutils.DeleteNamespace(t, k8sClient, namespace)
+	}
+	cleanup()
+	defer cleanup()
+
+	if err := exec.Command("kubectl", "create", "namespace", namespace).Run(); err != nil {
+		t.Fatalf("Failed to create namespace %s: %v", namespace, err)
+	}
+
+	// PHASE 1: start Faro before the namespace carries the label
+	config := &faro.Config{
+		OutputDir:  logDir,
+		LogLevel:   "debug",
+		JsonExport: true,
+		Resources: []faro.ResourceConfig{
+			{
+				GVR:                    "v1/configmaps",
+				Scope:                  faro.NamespaceScope,
+				NamespaceLabelSelector: "faro-test=ns-selector",
+			},
+		},
+	}
+
+	faroClient, err := faro.NewKubernetesClient()
+	if err != nil {
+		t.Fatalf("Failed to create Faro Kubernetes client: %v", err)
+	}
+	logger, err := faro.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create Faro logger: %v", err)
+	}
+	defer logger.Shutdown()
+
+	controller := faro.NewController(faroClient, logger, config)
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Failed to start Faro controller: %v", err)
+	}
+	defer controller.Stop()
+
+	// PHASE 2: label the namespace and create a ConfigMap in it
+	if err := exec.Command("kubectl", "label", "namespace", namespace, "faro-test=ns-selector").Run(); err != nil {
+		t.Fatalf("Failed to label namespace %s: %v", namespace, err)
+	}
+	time.Sleep(3 * time.Second)
+	if err := exec.Command("kubectl", "create", "configmap", "selected-config", "-n", namespace, "--from-literal=key=value").Run(); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+
+	// Wait for events to be processed
+	time.Sleep(5 * time.Second)
+
+	// PHASE 3: verify the ConfigMap of the labeled namespace is exported
+	for _, event := range testutils.ReadJSONEvents(t, logDir) {
+		if event.GVR == "v1/configmaps" && event.Namespace == namespace && event.Name == "selected-config" && event.EventType == "ADDED" {
+			t.Log("✅ ConfigMap in labeled namespace captured")
+			return
+		}
+	}
+	t.Error("❌ ADDED event for selected-config not found in JSON export")
+}
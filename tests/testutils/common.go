@@ -2,9 +2,12 @@
 package testutils
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -105,7 +108,8 @@ func ReadJSONEvents(t *testing.T, logDir string) []FaroJSONEvent {
 	return events
 }
 
-// readJSONFromFile reads and parses JSON events from a dedicated JSON export file
+// readJSONFromFile reads and parses JSON events from a dedicated JSON export file, which may be
+// a gzipped rotation archive (events-*.json.gz)
 func readJSONFromFile(t *testing.T, filename string) []FaroJSONEvent {
 	t.Helper()
 	
@@ -113,6 +117,15 @@ func readJSONFromFile(t *testing.T, filename string) []FaroJSONEvent {
 	if err != nil {
 		t.Fatalf("❌ ERROR: Failed to read JSON file %s: %v", filename, err)
 	}
+	if strings.HasSuffix(filename, ".gz") {
+		reader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			t.Fatalf("❌ ERROR: Failed to open gzipped JSON file %s: %v", filename, err)
+		}
+		if content, err = io.ReadAll(reader); err != nil {
+			t.Fatalf("❌ ERROR: Failed to decompress JSON file %s: %v", filename, err)
+		}
+	}
 	
 	var events []FaroJSONEvent
 	lines := strings.Split(string(content), "\n")
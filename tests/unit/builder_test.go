@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"reflect"
+	"testing"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+func TestBuildersMatchManualConstruction(t *testing.T) {
+	built := faro.NewConfig("/tmp/test").Add(
+		faro.WatchResource("v1/configmaps").InNamespaces("prod", "staging").WithLabels("app=web").Named("app-config"),
+		faro.WatchResource("v1/namespaces").Cluster(),
+		faro.WatchResource("v1/secrets").InLabeledNamespaces("team=payments"),
+		faro.WatchResource("v1/pods").OnNode("node-a"),
+	)
+
+	manual := &faro.Config{
+		OutputDir: "/tmp/test",
+		LogLevel:  "info",
+		Resources: []faro.ResourceConfig{
+			{
+				GVR:            "v1/configmaps",
+				Scope:          faro.NamespaceScope,
+				NamespaceNames: []string{"prod", "staging"},
+				LabelSelector:  "app=web",
+				NameSelector:   "app-config",
+			},
+			{
+				GVR:   "v1/namespaces",
+				Scope: faro.ClusterScope,
+			},
+			{
+				GVR:                    "v1/secrets",
+				Scope:                  faro.NamespaceScope,
+				NamespaceLabelSelector: "team=payments",
+			},
+			{
+				GVR:      "v1/pods",
+				Scope:    faro.NamespaceScope,
+				NodeName: "node-a",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(built, manual) {
+		t.Errorf("builder config differs from manual config:\n got: %+v\nwant: %+v", built, manual)
+	}
+	if err := built.Validate(); err != nil {
+		t.Errorf("built config failed validation: %v", err)
+	}
+}
+
+func TestBuilderDoesNotShareNamespaceSlices(t *testing.T) {
+	base := faro.WatchResource("v1/secrets").InNamespaces("a")
+	first := base.InNamespaces("b")
+	second := base.InNamespaces("c")
+
+	if !reflect.DeepEqual(first.NamespaceNames, []string{"a", "b"}) || !reflect.DeepEqual(second.NamespaceNames, []string{"a", "c"}) {
+		t.Errorf("derived configs share namespace storage: %v %v", first.NamespaceNames, second.NamespaceNames)
+	}
+}
@@ -0,0 +1,146 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+// testKubeconfig is a minimal kubeconfig pointing at an unreachable API server
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+    insecure-skip-tls-verify: true
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test-token
+`
+
+// writeTestKubeconfig writes testKubeconfig into a temp dir and returns its path
+func writeTestKubeconfig(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestNewKubernetesClientWithConfigAppliesOptions(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeTestKubeconfig(t))
+
+	client, err := faro.NewKubernetesClientWithConfig(faro.ClientOptions{
+		QPS:       50,
+		Burst:     100,
+		Timeout:   15 * time.Second,
+		UserAgent: "faro-test/1.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if client.Config.QPS != 50 {
+		t.Errorf("expected QPS 50, got %v", client.Config.QPS)
+	}
+	if client.Config.Burst != 100 {
+		t.Errorf("expected Burst 100, got %d", client.Config.Burst)
+	}
+	if client.Config.Timeout != 0 {
+		t.Errorf("expected Timeout not to be set on the REST config, where it would cut watches, got %v", client.Config.Timeout)
+	}
+	if client.Config.UserAgent != "faro-test/1.0" {
+		t.Errorf("expected UserAgent faro-test/1.0, got %q", client.Config.UserAgent)
+	}
+}
+
+func TestClientTimeoutSparesWatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond) // Slower than the client timeout
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") == "true" {
+			return // Empty watch stream, ends when the body closes
+		}
+		fmt.Fprint(w, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"slow","namespace":"default"}}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	kubeconfig := strings.Replace(testKubeconfig, "https://127.0.0.1:6443", server.URL, 1)
+	if err := os.WriteFile(path, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	t.Setenv("KUBECONFIG", path)
+
+	client, err := faro.NewKubernetesClientWithConfig(faro.ClientOptions{Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	configMaps := client.Dynamic.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("default")
+
+	if _, err := configMaps.Get(context.Background(), "slow", metav1.GetOptions{}); err == nil {
+		t.Error("expected a GET slower than the timeout to fail")
+	}
+	watcher, err := configMaps.Watch(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("expected a watch slower than the timeout to be established, got: %v", err)
+	}
+	watcher.Stop()
+}
+
+func TestNewKubernetesClientWithConfigKeepsDefaults(t *testing.T) {
+	t.Setenv("KUBECONFIG", writeTestKubeconfig(t))
+
+	client, err := faro.NewKubernetesClientWithConfig(faro.ClientOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if client.Config.QPS != 0 || client.Config.Burst != 0 || client.Config.Timeout != 0 {
+		t.Errorf("expected unset options to keep client-go defaults, got QPS=%v Burst=%d Timeout=%v",
+			client.Config.QPS, client.Config.Burst, client.Config.Timeout)
+	}
+}
+
+func TestNewKubernetesClientFromKubeconfig(t *testing.T) {
+	// An explicit path wins over $KUBECONFIG
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+
+	client, err := faro.NewKubernetesClientFromKubeconfig(writeTestKubeconfig(t))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if client.Config.Host != "https://127.0.0.1:6443" {
+		t.Errorf("expected host from kubeconfig, got %q", client.Config.Host)
+	}
+	if client.Dynamic == nil || client.Discovery == nil {
+		t.Error("expected dynamic and discovery clients to be created")
+	}
+}
+
+func TestNewKubernetesClientFromKubeconfigMissingFile(t *testing.T) {
+	if _, err := faro.NewKubernetesClientFromKubeconfig(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing kubeconfig file")
+	}
+}
@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+func TestCloudEventsSinkWritesSpecEnvelope(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cloudevents.json")
+	sink, err := faro.NewCloudEventsSink(path, "prod-east")
+	if err != nil {
+		t.Fatalf("Failed to create CloudEvents sink: %v", err)
+	}
+
+	configMap := newConfigMap("test-ns", "settings", "uid-1", map[string]string{"app": "web"})
+	configMap.SetResourceVersion("42")
+	timestamp := time.Date(2025, 11, 10, 14, 33, 2, 0, time.UTC)
+	if err := sink.OnMatched(faro.MatchedEvent{EventType: "ADDED", GVR: "v1/configmaps", Object: configMap, Timestamp: timestamp}); err != nil {
+		t.Fatalf("OnMatched failed: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read sink file: %v", err)
+	}
+	var envelope map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(content))), &envelope); err != nil {
+		t.Fatalf("Sink output is not one JSON envelope: %v\n%s", err, content)
+	}
+
+	// Required context attributes, then the optional ones Faro sets
+	expected := map[string]string{
+		"specversion":     "1.0",
+		"id":              "uid-1-42-ADDED",
+		"source":          "/faro/prod-east/v1/configmaps",
+		"type":            "dev.faro.added",
+		"subject":         "test-ns/settings",
+		"time":            "2025-11-10T14:33:02Z",
+		"datacontenttype": "application/json",
+	}
+	for attribute, want := range expected {
+		if got, _ := envelope[attribute].(string); got != want {
+			t.Errorf("expected %s %q, got %q", attribute, want, got)
+		}
+	}
+
+	data, ok := envelope["data"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected data to hold the JSON event, got %v", envelope["data"])
+	}
+	if data["eventType"] != "ADDED" || data["name"] != "settings" || data["uid"] != "uid-1" {
+		t.Errorf("unexpected data: %v", data)
+	}
+}
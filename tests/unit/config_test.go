@@ -1,7 +1,9 @@
 package unit
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	faro "github.com/T0MASD/faro/pkg"
@@ -37,6 +39,24 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "cluster scope with namespace names",
+			config: faro.Config{
+				OutputDir: "/tmp/test",
+				LogLevel:  "info",
+				Resources: []faro.ResourceConfig{{GVR: "v1/namespaces", Scope: faro.ClusterScope, NamespaceNames: []string{"prod"}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "cluster scope with namespace label selector",
+			config: faro.Config{
+				OutputDir: "/tmp/test",
+				LogLevel:  "info",
+				Resources: []faro.ResourceConfig{{GVR: "v1/namespaces", Scope: faro.ClusterScope, NamespaceLabelSelector: "team=payments"}},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -122,4 +142,150 @@ func TestGetLogDir(t *testing.T) {
 	if result != expected {
 		t.Errorf("expected %s, got %s", expected, result)
 	}
-}
\ No newline at end of file
+}
+
+func TestSampleRateValidation(t *testing.T) {
+	config := &faro.Config{
+		OutputDir: "/tmp/test",
+		LogLevel:  "info",
+		Resources: []faro.ResourceConfig{{GVR: "v1/events", SampleRate: 1.5}},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected sample_rate above 1.0 to fail validation")
+	}
+}
+
+func TestDedupModeValidation(t *testing.T) {
+	config := &faro.Config{
+		OutputDir:     "/tmp/test",
+		LogLevel:      "info",
+		DedupWindowMs: 100,
+		DedupMode:     "sideways",
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected unknown dedup_mode to fail validation")
+	}
+}
+
+// writeConfigFile writes a YAML config fixture into dir
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadFromDirectoryMergesResources(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, dir, "00-base.yaml", `
+output_dir: /tmp/faro
+log_level: info
+resources:
+  - gvr: v1/configmaps
+    scope: Namespaced
+    namespace_names: ["default"]
+`)
+	writeConfigFile(t, dir, "10-extra.yaml", `
+log_level: debug
+resources:
+  - gvr: v1/secrets
+    scope: Namespaced
+    namespace_names: ["default"]
+`)
+
+	config := &faro.Config{}
+	if err := config.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("LoadFromDirectory failed: %v", err)
+	}
+
+	if len(config.Resources) != 2 {
+		t.Fatalf("expected 2 merged resources, got %d", len(config.Resources))
+	}
+	if config.Resources[0].GVR != "v1/configmaps" || config.Resources[1].GVR != "v1/secrets" {
+		t.Errorf("unexpected merged resources: %+v", config.Resources)
+	}
+	if config.OutputDir != "/tmp/faro" || config.LogLevel != "debug" {
+		t.Errorf("expected settings from both files, got output_dir=%q log_level=%q", config.OutputDir, config.LogLevel)
+	}
+}
+
+func TestLoadFromYAMLResolvesIncludes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "resources"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeConfigFile(t, filepath.Join(dir, "resources"), "secrets.yaml", `
+resources:
+  - gvr: v1/secrets
+    scope: Namespaced
+`)
+	mainFile := writeConfigFile(t, dir, "main.yaml", `
+output_dir: /tmp/faro
+include:
+  - resources/secrets.yaml
+resources:
+  - gvr: v1/configmaps
+    scope: Namespaced
+`)
+
+	config := &faro.Config{}
+	if err := config.LoadFromYAML(mainFile); err != nil {
+		t.Fatalf("LoadFromYAML failed: %v", err)
+	}
+	if len(config.Resources) != 2 {
+		t.Fatalf("expected 2 resources after include, got %d", len(config.Resources))
+	}
+}
+
+func TestLoadFromDirectoryRejectsDuplicateGVR(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, dir, "a.yaml", "resources:\n  - gvr: v1/configmaps\n")
+	writeConfigFile(t, dir, "b.yaml", "resources:\n  - gvr: v1/configmaps\n")
+
+	config := &faro.Config{}
+	err := config.LoadFromDirectory(dir)
+	if err == nil || !strings.Contains(err.Error(), "duplicate GVR v1/configmaps") {
+		t.Fatalf("expected duplicate GVR error, got %v", err)
+	}
+}
+
+func TestOutputDirNotWritable(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	readOnly := filepath.Join(tmpDir, "read-only")
+	if err := os.Mkdir(readOnly, 0555); err != nil {
+		t.Fatalf("Failed to create read-only directory: %v", err)
+	}
+	notADir := filepath.Join(tmpDir, "file")
+	if err := os.WriteFile(notADir, nil, 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		outputDir     string
+		usesFileModes bool
+	}{
+		{"read-only directory", filepath.Join(readOnly, "output"), true},
+		{"path below a file", filepath.Join(notADir, "output"), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.usesFileModes && os.Geteuid() == 0 {
+				t.Skip("root ignores directory permissions")
+			}
+
+			config := &faro.Config{OutputDir: tc.outputDir, LogLevel: "info"}
+			err := config.Validate()
+			if err == nil || !strings.Contains(err.Error(), "output directory "+tc.outputDir+" is not writable") {
+				t.Errorf("expected a not writable error from Validate, got %v", err)
+			}
+
+			// Embedders calling NewLogger without Validate get an error too, not a panic
+			if _, err := faro.NewLogger(config); err == nil || !strings.Contains(err.Error(), "failed to create log directory") {
+				t.Errorf("expected a log directory error from NewLogger, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,4140 @@
+package unit
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	goruntime "runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+// fakeAPIResources is the API surface served by the fake discovery client
+var fakeAPIResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+			{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+			{Name: "services", Kind: "Service", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+			{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: []string{"get", "list", "watch"}},
+		},
+	},
+}
+
+// fakeListKinds maps every fake GVR to its list kind for the fake dynamic client
+var fakeListKinds = map[schema.GroupVersionResource]string{
+	{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	{Version: "v1", Resource: "secrets"}:    "SecretList",
+	{Version: "v1", Resource: "services"}:   "ServiceList",
+	{Version: "v1", Resource: "namespaces"}: "NamespaceList",
+}
+
+// newFakeClient creates a KubernetesClient backed by fake dynamic and discovery clients
+func newFakeClient(objects ...runtime.Object) (*faro.KubernetesClient, *dynamicfake.FakeDynamicClient) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), fakeListKinds, objects...)
+	discoveryClient := &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: fakeAPIResources}}
+
+	return &faro.KubernetesClient{
+		Dynamic:   dynamicClient,
+		Discovery: discoveryClient,
+	}, dynamicClient
+}
+
+// newTestConfig creates a config writing logs into a temporary directory
+func newTestConfig(t testing.TB, resources ...faro.ResourceConfig) *faro.Config {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "faro-controller-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
+
+	return &faro.Config{
+		OutputDir: tmpDir,
+		LogLevel:  "info",
+		Resources: resources,
+	}
+}
+
+// newTestLogger creates a logger for the given config and shuts it down after the test
+func newTestLogger(t testing.TB, config *faro.Config) *faro.Logger {
+	t.Helper()
+
+	logger, err := faro.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	t.Cleanup(logger.Shutdown)
+	return logger
+}
+
+// newObject creates an unstructured object for seeding the fake cluster
+func newObject(apiVersion, kind, namespace, name, uid string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetUID(types.UID(uid))
+	obj.SetLabels(labels)
+	return obj
+}
+
+// newConfigMap creates an unstructured ConfigMap for seeding the fake cluster
+func newConfigMap(namespace, name, uid string, labels map[string]string) *unstructured.Unstructured {
+	return newObject("v1", "ConfigMap", namespace, name, uid, labels)
+}
+
+// startTestController starts the controller and stops it when the test finishes
+func startTestController(t testing.TB, controller *faro.Controller) {
+	t.Helper()
+
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Failed to start controller: %v", err)
+	}
+	t.Cleanup(controller.Stop)
+}
+
+// readJSONEvents reads all exported JSON events from the config's log directory, including
+// gzipped rotation archives
+func readJSONEvents(t *testing.T, config *faro.Config) []faro.JSONEvent {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(config.GetLogDir(), "events-*.json"))
+	if err != nil {
+		t.Fatalf("Failed to list JSON files: %v", err)
+	}
+	archives, _ := filepath.Glob(filepath.Join(config.GetLogDir(), "events-*.json.gz"))
+	files = append(archives, files...)
+
+	var events []faro.JSONEvent
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Failed to open JSON file: %v", err)
+		}
+		var reader io.Reader = file
+		if strings.HasSuffix(path, ".gz") {
+			gz, err := gzip.NewReader(file)
+			if err != nil {
+				t.Fatalf("Failed to open gzipped JSON file %s: %v", path, err)
+			}
+			reader = gz
+		}
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			var event faro.JSONEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err == nil {
+				events = append(events, event)
+			}
+		}
+		file.Close()
+	}
+	return events
+}
+
+// waitForJSONEvents polls the JSON export until at least count events are present
+func waitForJSONEvents(t *testing.T, config *faro.Config, count int) []faro.JSONEvent {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		events := readJSONEvents(t, config)
+		if len(events) >= count {
+			return events
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d JSON events, got %d: %+v", count, len(events), events)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// recordingHandler collects matched events delivered to it
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []faro.MatchedEvent
+}
+
+func (r *recordingHandler) OnMatched(event faro.MatchedEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+// Events returns a snapshot of the recorded events
+func (r *recordingHandler) Events() []faro.MatchedEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]faro.MatchedEvent(nil), r.events...)
+}
+
+// waitForStableCount waits until the handler stops receiving events and returns the final count
+func (r *recordingHandler) waitForStableCount(t *testing.T) int {
+	t.Helper()
+
+	deadline := time.Now().Add(15 * time.Second)
+	last, stableSince := -1, time.Now()
+	for time.Now().Before(deadline) {
+		count := len(r.Events())
+		if count != last {
+			last, stableSince = count, time.Now()
+		} else if count > 0 && time.Since(stableSince) > 500*time.Millisecond {
+			return count
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for handler event count to settle (last: %d)", last)
+	return 0
+}
+
+// waitFor polls condition until it returns true or fails the test after a timeout
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// countEvents returns how many events have the given event type
+func countEvents(events []faro.MatchedEvent, eventType string) int {
+	count := 0
+	for _, event := range events {
+		if event.EventType == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPreflightRBACCheckReportsDeniedGVR(t *testing.T) {
+	client, dynamicClient := newFakeClient()
+
+	// Deny list on secrets, allow everything else
+	dynamicClient.PrependReactor("list", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", errors.New("rbac: access denied"))
+	})
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}},
+		faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}},
+	)
+	config.PreflightRBACCheck = true
+	config.PreflightRBACFailFast = true
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	defer controller.Stop()
+
+	err := controller.Start()
+	if err == nil {
+		t.Fatal("expected Start to fail when preflight finds a denied GVR")
+	}
+	if !strings.Contains(err.Error(), "v1/secrets@test-ns") {
+		t.Errorf("expected error to report v1/secrets@test-ns, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "v1/configmaps") {
+		t.Errorf("expected v1/configmaps to pass preflight, got: %v", err)
+	}
+}
+
+func TestPreflightRBACCheckWarnsWithoutFailFast(t *testing.T) {
+	client, dynamicClient := newFakeClient()
+	dynamicClient.PrependReactor("list", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", errors.New("rbac: access denied"))
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}})
+	config.PreflightRBACCheck = true
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	defer controller.Stop()
+
+	if err := controller.Start(); err != nil {
+		t.Fatalf("expected Start to succeed without fail-fast, got: %v", err)
+	}
+}
+
+func TestJSONExportKeyAllowList(t *testing.T) {
+	cm := newConfigMap("test-ns", "app-config", "uid-1", map[string]string{
+		"app":      "web",
+		"internal": "secret-team",
+	})
+	cm.SetAnnotations(map[string]string{
+		"owner":                      "team-a",
+		"kubectl.kubernetes.io/last": "{\"big\":\"payload\"}",
+	})
+	client, _ := newFakeClient(cm)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.JsonExport = true
+	config.JsonLabelKeys = []string{"app"}
+	config.JsonAnnotationKeys = []string{"owner"}
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	event := waitForJSONEvents(t, config, 1)[0]
+	if len(event.Labels) != 1 || event.Labels["app"] != "web" {
+		t.Errorf("expected only the app label, got %v", event.Labels)
+	}
+	if len(event.Annotations) != 1 || event.Annotations["owner"] != "team-a" {
+		t.Errorf("expected only the owner annotation, got %v", event.Annotations)
+	}
+}
+
+func TestJSONExportAllKeysByDefault(t *testing.T) {
+	cm := newConfigMap("test-ns", "app-config", "uid-1", map[string]string{"app": "web", "tier": "frontend"})
+	client, _ := newFakeClient(cm)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.JsonExport = true
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	event := waitForJSONEvents(t, config, 1)[0]
+	if len(event.Labels) != 2 {
+		t.Errorf("expected all labels to be exported, got %v", event.Labels)
+	}
+}
+
+func TestStartTwiceReturnsErrAlreadyStarted(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	if err := controller.Start(); !errors.Is(err, faro.ErrAlreadyStarted) {
+		t.Fatalf("expected ErrAlreadyStarted on second Start, got: %v", err)
+	}
+
+	configInformers, _ := controller.GetActiveInformers()
+	if configInformers != 1 {
+		t.Errorf("expected 1 informer after double Start, got %d", configInformers)
+	}
+}
+
+func TestWaitForNamespacesReturnsOnceCreated(t *testing.T) {
+	client, dynamicClient := newFakeClient(newObject("v1", "Namespace", "", "present", "uid-ns-1", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"present"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- controller.WaitForNamespaces(ctx, []string{"present", "later"}) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected WaitForNamespaces to block until 'later' exists, returned %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	namespaces := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"})
+	if _, err := namespaces.Create(context.Background(), newObject("v1", "Namespace", "", "later", "uid-ns-2", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create namespace: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected WaitForNamespaces to succeed, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForNamespaces did not return after the namespace was created")
+	}
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancelExpired()
+	if err := controller.WaitForNamespaces(expired, []string{"never"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline error for a namespace that never appears, got %v", err)
+	}
+}
+
+func TestStopBeforeStartIsNoop(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+
+	done := make(chan struct{})
+	go func() {
+		controller.Stop()
+		controller.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop on a never-started controller did not return")
+	}
+
+	if controller.IsReady() {
+		t.Error("expected never-started controller to report not ready")
+	}
+}
+
+// fakeClusterClient implements faro.ClusterClient directly on the client-go fakes
+type fakeClusterClient struct {
+	dynamic   *dynamicfake.FakeDynamicClient
+	discovery *discoveryfake.FakeDiscovery
+}
+
+func (f *fakeClusterClient) DynamicClient() faro.DynamicClient     { return f.dynamic }
+func (f *fakeClusterClient) DiscoveryLister() faro.DiscoveryLister { return f.discovery }
+
+func TestSharedDiscoveryCacheDiscoversOnce(t *testing.T) {
+	discovery := &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: fakeAPIResources}}
+	cache := faro.NewDiscoveryCache(time.Minute)
+
+	var handlers []*recordingHandler
+	for _, name := range []string{"first", "second"} {
+		client := &fakeClusterClient{
+			dynamic:   dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), fakeListKinds, newConfigMap("test-ns", name, "uid-"+name, nil)),
+			discovery: discovery,
+		}
+		config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+		controller := faro.NewControllerWithDiscovery(client, newTestLogger(t, config), config, cache)
+		handler := &recordingHandler{}
+		controller.AddEventHandler(handler)
+		startTestController(t, controller)
+		handlers = append(handlers, handler)
+	}
+
+	for i, handler := range handlers {
+		waitFor(t, fmt.Sprintf("ADDED event on controller %d", i+1), func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+	}
+
+	groupLists := 0
+	for _, action := range discovery.Actions() {
+		if action.GetResource().Resource == "group" {
+			groupLists++
+		}
+	}
+	if groupLists != 1 || cache.Discoveries() != 1 {
+		t.Errorf("expected one discovery for both controllers, got %d group lists and %d discoveries", groupLists, cache.Discoveries())
+	}
+}
+
+func TestReconcileWithInjectedFakeClients(t *testing.T) {
+	client := &fakeClusterClient{
+		dynamic:   dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), fakeListKinds, newConfigMap("test-ns", "settings", "uid-1", nil)),
+		discovery: &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: fakeAPIResources}},
+	}
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "initial ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+	if _, err := controller.GetObject("v1/configmaps", "test-ns", "settings"); err != nil {
+		t.Errorf("expected the ConfigMap in the informer cache: %v", err)
+	}
+
+	configMaps := client.dynamic.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	updated := newConfigMap("test-ns", "settings", "uid-1", map[string]string{"revision": "2"})
+	if _, err := configMaps.Update(context.Background(), updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+	waitFor(t, "UPDATED event", func() bool { return countEvents(handler.Events(), "UPDATED") == 1 })
+
+	if err := configMaps.Delete(context.Background(), "settings", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+	waitFor(t, "DELETED event", func() bool { return countEvents(handler.Events(), "DELETED") == 1 })
+
+	for _, event := range handler.Events() {
+		if event.GVR != "v1/configmaps" || event.Object.GetName() != "settings" {
+			t.Errorf("unexpected event %s for %s %s", event.EventType, event.GVR, event.Object.GetName())
+		}
+	}
+}
+
+func TestShutdownCallbacksRunBeforeInformersStop(t *testing.T) {
+	client, _ := newFakeClient(newConfigMap("test-ns", "settings", "uid-1", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	var calls []string
+	controller.SetShutdownCallback(func() {
+		// Caches are still readable while callbacks run
+		if _, err := controller.GetObject("v1/configmaps", "test-ns", "settings"); err != nil {
+			t.Errorf("expected informer cache to be live during shutdown callback: %v", err)
+		}
+		calls = append(calls, "first")
+	})
+	controller.SetShutdownCallback(func() { calls = append(calls, "second") })
+
+	if err := controller.Start(); err != nil {
+		t.Fatalf("Failed to start controller: %v", err)
+	}
+	waitFor(t, "informer sync", controller.Ready)
+
+	controller.Stop()
+	controller.Stop() // Callbacks run once
+
+	if !reflect.DeepEqual(calls, []string{"first", "second"}) {
+		t.Errorf("expected both callbacks once in registration order, got %v", calls)
+	}
+}
+
+func TestSampleRateKeepsConfiguredFraction(t *testing.T) {
+	const total = 2000
+	var objects []runtime.Object
+	for i := 0; i < total; i++ {
+		objects = append(objects, newConfigMap("test-ns", fmt.Sprintf("cm-%d", i), fmt.Sprintf("uid-%d", i), nil))
+	}
+	client, _ := newFakeClient(objects...)
+
+	config := newTestConfig(t, faro.ResourceConfig{
+		GVR:            "v1/configmaps",
+		NamespaceNames: []string{"test-ns"},
+		SampleRate:     0.25,
+	})
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	kept := handler.waitForStableCount(t)
+	fraction := float64(kept) / total
+	if fraction < 0.18 || fraction > 0.32 {
+		t.Errorf("expected roughly 25%% of events to be kept, got %d/%d (%.2f)", kept, total, fraction)
+	}
+}
+
+func TestMaxEventsPerSecThrottlesBursts(t *testing.T) {
+	const total = 200
+	var objects []runtime.Object
+	for i := 0; i < total; i++ {
+		objects = append(objects, newConfigMap("test-ns", fmt.Sprintf("cm-%d", i), fmt.Sprintf("uid-%d", i), nil))
+	}
+	client, _ := newFakeClient(objects...)
+
+	config := newTestConfig(t, faro.ResourceConfig{
+		GVR:             "v1/configmaps",
+		NamespaceNames:  []string{"test-ns"},
+		MaxEventsPerSec: 20,
+	})
+	enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	// The initial list delivers every object at once; only about one second's worth passes
+	kept := handler.waitForStableCount(t)
+	throttled := controller.Metrics().ThrottledEventCount("v1/configmaps")
+	if kept >= total/2 {
+		t.Errorf("expected the burst to be throttled to about 20 events, kept %d/%d", kept, total)
+	}
+	if kept+int(throttled) != total {
+		t.Errorf("expected every event to be kept or counted as throttled, kept %d and throttled %v of %d", kept, throttled, total)
+	}
+}
+
+func TestCoalesceShortLivedObjects(t *testing.T) {
+	for _, mode := range []string{faro.ShortLivedModeEphemeral, faro.ShortLivedModeDrop} {
+		t.Run(mode, func(t *testing.T) {
+			client, dynamicClient := newFakeClient()
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+			config.DedupWindowMs = 500
+			config.CoalesceShortLived = true
+			config.ShortLivedMode = mode
+			if err := config.Validate(); err != nil {
+				t.Fatalf("expected short_lived_mode %q to be valid: %v", mode, err)
+			}
+
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			handler := &recordingHandler{}
+			controller.AddEventHandler(handler)
+			startTestController(t, controller)
+
+			// The Job-like object is gone before its ADDED leaves the dedup window
+			configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+			if _, err := configMaps.Create(context.Background(), newConfigMap("test-ns", "ephemeral", "uid-1", nil), metav1.CreateOptions{}); err != nil {
+				t.Fatalf("Failed to create ConfigMap: %v", err)
+			}
+			time.Sleep(50 * time.Millisecond)
+			if err := configMaps.Delete(context.Background(), "ephemeral", metav1.DeleteOptions{}); err != nil {
+				t.Fatalf("Failed to delete ConfigMap: %v", err)
+			}
+
+			// A long-lived object marks the point by which the short-lived one would have been reported
+			if _, err := configMaps.Create(context.Background(), newConfigMap("test-ns", "long-lived", "uid-2", nil), metav1.CreateOptions{}); err != nil {
+				t.Fatalf("Failed to create ConfigMap: %v", err)
+			}
+			waitFor(t, "ADDED for the long-lived object", func() bool {
+				for _, event := range handler.Events() {
+					if event.EventType == "ADDED" && event.Object.GetName() == "long-lived" {
+						return true
+					}
+				}
+				return false
+			})
+
+			var got []string
+			for _, event := range handler.Events() {
+				if event.Object.GetName() == "ephemeral" {
+					got = append(got, event.EventType)
+				}
+			}
+			want := []string{faro.EventTypeEphemeral}
+			if mode == faro.ShortLivedModeDrop {
+				want = nil
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %v for the short-lived object, got %v", want, got)
+			}
+		})
+	}
+
+	config := newTestConfig(t)
+	config.CoalesceShortLived = true
+	if err := config.Validate(); err == nil {
+		t.Error("expected coalesce_short_lived without dedup_window_ms to be rejected")
+	}
+}
+
+func TestDedupWindowCollapsesRapidUpdates(t *testing.T) {
+	cm := newConfigMap("test-ns", "flapping", "uid-1", nil)
+	client, dynamicClient := newFakeClient(cm)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.DedupWindowMs = 500
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "initial ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+
+	// Flap the object several times inside one window
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	for i := 0; i < 10; i++ {
+		cm.SetLabels(map[string]string{"revision": fmt.Sprintf("%d", i)})
+		if _, err := configMaps.Update(context.Background(), cm, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("Failed to update ConfigMap: %v", err)
+		}
+	}
+
+	waitFor(t, "collapsed UPDATED event", func() bool { return countEvents(handler.Events(), "UPDATED") >= 1 })
+	time.Sleep(time.Second) // Give any extra (uncollapsed) events time to arrive
+
+	if updates := countEvents(handler.Events(), "UPDATED"); updates != 1 {
+		t.Errorf("expected 10 rapid updates to collapse into 1 event, got %d", updates)
+	}
+}
+
+func TestDedupWindowFlushesOnDelete(t *testing.T) {
+	cm := newConfigMap("test-ns", "short-lived", "uid-1", nil)
+	client, dynamicClient := newFakeClient(cm)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.DedupWindowMs = 60000 // Far longer than the test - only DELETE can flush it
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	waitFor(t, "informer to observe the ConfigMap", func() bool {
+		_, err := configMaps.Get(context.Background(), "short-lived", metav1.GetOptions{})
+		return err == nil
+	})
+	time.Sleep(200 * time.Millisecond)
+	if err := configMaps.Delete(context.Background(), "short-lived", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+
+	waitFor(t, "DELETED event despite open dedup window", func() bool { return countEvents(handler.Events(), "DELETED") == 1 })
+}
+
+// concurrencyMiddleware slows JSON export down and records how many events for one object overlap
+type concurrencyMiddleware struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	eventTypes  []string
+}
+
+func (m *concurrencyMiddleware) ProcessBeforeJSON(eventType, gvr, namespace, name, uid string, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool) {
+	m.mu.Lock()
+	m.inFlight++
+	if m.inFlight > m.maxInFlight {
+		m.maxInFlight = m.inFlight
+	}
+	m.eventTypes = append(m.eventTypes, eventType)
+	m.mu.Unlock()
+
+	time.Sleep(100 * time.Millisecond)
+
+	m.mu.Lock()
+	m.inFlight--
+	m.mu.Unlock()
+	return obj, true
+}
+
+func TestEventsForOneObjectAreProcessedSequentially(t *testing.T) {
+	cm := newConfigMap("test-ns", "settings", "uid-1", nil)
+	client, dynamicClient := newFakeClient()
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	middleware := &concurrencyMiddleware{}
+	controller.AddJSONMiddleware(middleware)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	// Create and update back to back so both events are queued while the first is still exporting
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if _, err := configMaps.Create(context.Background(), cm, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	cm.SetLabels(map[string]string{"revision": "2"})
+	if _, err := configMaps.Update(context.Background(), cm, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+
+	waitFor(t, "both events exported", func() bool {
+		middleware.mu.Lock()
+		defer middleware.mu.Unlock()
+		return len(middleware.eventTypes) == 2 && middleware.inFlight == 0
+	})
+
+	middleware.mu.Lock()
+	defer middleware.mu.Unlock()
+	if middleware.maxInFlight != 1 {
+		t.Errorf("expected events for one object to be processed one at a time, got %d concurrently", middleware.maxInFlight)
+	}
+	if !reflect.DeepEqual(middleware.eventTypes, []string{"ADDED", "UPDATED"}) {
+		t.Errorf("expected ADDED then UPDATED, got %v", middleware.eventTypes)
+	}
+}
+
+func TestSuppressInitialAddsEmitsOnlyChanges(t *testing.T) {
+	existing := newConfigMap("test-ns", "existing", "uid-1", nil)
+	client, dynamicClient := newFakeClient(existing)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.SuppressInitialAdds = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	time.Sleep(500 * time.Millisecond) // Give any (unsuppressed) initial events time to arrive
+	if count := len(handler.Events()); count != 0 {
+		t.Fatalf("expected no events for pre-existing objects, got %d", count)
+	}
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	existing.SetLabels(map[string]string{"revision": "2"})
+	if _, err := configMaps.Update(context.Background(), existing, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+	if _, err := configMaps.Create(context.Background(), newConfigMap("test-ns", "created", "uid-2", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	waitFor(t, "post-sync UPDATED and ADDED events", func() bool {
+		events := handler.Events()
+		return countEvents(events, "UPDATED") == 1 && countEvents(events, "ADDED") == 1
+	})
+
+	// The UID of a suppressed object is still cached, so its deletion is reported
+	if err := configMaps.Delete(context.Background(), "existing", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+	waitFor(t, "DELETED event", func() bool { return countEvents(handler.Events(), "DELETED") == 1 })
+	for _, event := range handler.Events() {
+		if event.EventType == "ADDED" && event.Object.GetName() != "created" {
+			t.Errorf("unexpected ADDED event for %s", event.Object.GetName())
+		}
+	}
+}
+
+// newService creates an unstructured Service of the given spec.type
+func newService(namespace, name, uid, serviceType string) *unstructured.Unstructured {
+	service := newObject("v1", "Service", namespace, name, uid, nil)
+	unstructured.SetNestedField(service.Object, serviceType, "spec", "type")
+	return service
+}
+
+func TestJSONPathSelectorsFilterOnNestedFields(t *testing.T) {
+	client, dynamicClient := newFakeClient(
+		newService("test-ns", "public", "uid-1", "LoadBalancer"),
+		newService("test-ns", "internal", "uid-2", "ClusterIP"),
+	)
+
+	config := newTestConfig(t, faro.ResourceConfig{
+		GVR:               "v1/services",
+		NamespaceNames:    []string{"test-ns"},
+		JSONPathSelectors: map[string]string{"spec.type": "LoadBalancer"},
+	})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	if count := handler.waitForStableCount(t); count != 1 {
+		t.Fatalf("expected only the LoadBalancer service, got %d events", count)
+	}
+
+	services := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "services"}).Namespace("test-ns")
+	if err := services.Delete(context.Background(), "internal", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete Service: %v", err)
+	}
+	if err := services.Delete(context.Background(), "public", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete Service: %v", err)
+	}
+	waitFor(t, "DELETED event", func() bool { return countEvents(handler.Events(), "DELETED") >= 1 })
+	time.Sleep(200 * time.Millisecond) // Give a filtered-out deletion time to arrive
+
+	for _, event := range handler.Events() {
+		if event.Object.GetName() != "public" {
+			t.Errorf("unexpected %s event for %s", event.EventType, event.Object.GetName())
+		}
+	}
+	if deleted := countEvents(handler.Events(), "DELETED"); deleted != 1 {
+		t.Errorf("expected one DELETED event, got %d", deleted)
+	}
+}
+
+func TestEmitSyncEventsOncePerInformer(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("ns-a", "first", "uid-1", nil),
+		newConfigMap("ns-a", "second", "uid-2", nil),
+	)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"ns-a", "ns-b"}})
+	config.EmitSyncEvents = true
+	config.JsonExport = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	// ns-b is empty but still reports its sync
+	handler.waitForStableCount(t)
+	synced := map[string]int{}
+	for _, event := range handler.Events() {
+		if event.EventType == "SYNCED" {
+			if event.GVR != "v1/configmaps" || event.Object != nil {
+				t.Errorf("unexpected SYNCED event %+v", event)
+			}
+			synced[event.Key]++
+		}
+	}
+	if !reflect.DeepEqual(synced, map[string]int{"ns-a": 1, "ns-b": 1}) {
+		t.Errorf("expected one SYNCED event per namespace, got %v", synced)
+	}
+	if added := countEvents(handler.Events(), "ADDED"); added != 2 {
+		t.Errorf("expected 2 ADDED events, got %d", added)
+	}
+
+	exported := map[string]int{}
+	for _, event := range waitForJSONEvents(t, config, 4) {
+		if event.EventType == "SYNCED" {
+			exported[event.Namespace]++
+		}
+	}
+	if !reflect.DeepEqual(exported, map[string]int{"ns-a": 1, "ns-b": 1}) {
+		t.Errorf("expected one exported SYNCED event per namespace, got %v", exported)
+	}
+}
+func TestUIDStoreResolvesDeletesMissedAcrossRestart(t *testing.T) {
+	store := faro.NewMemoryUIDStore()
+
+	client, _ := newFakeClient(
+		newConfigMap("test-ns", "doomed", "uid-doomed", nil),
+		newConfigMap("test-ns", "kept", "uid-kept", nil),
+	)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	first := faro.NewController(client, newTestLogger(t, config), config)
+	first.SetUIDStore(store)
+	handler := &recordingHandler{}
+	first.AddEventHandler(handler)
+	startTestController(t, first)
+	handler.waitForStableCount(t)
+	first.Stop()
+
+	if uid, found, _ := store.Get("v1/configmaps/test-ns/doomed"); !found || uid != "uid-doomed" {
+		t.Fatalf("expected stored UID uid-doomed, got %q (found: %t)", uid, found)
+	}
+
+	// "doomed" is deleted while no controller is running
+	restartedClient, _ := newFakeClient(newConfigMap("test-ns", "kept", "uid-kept", nil))
+	restartedConfig := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	restartedConfig.JsonExport = true
+	restarted := faro.NewController(restartedClient, newTestLogger(t, restartedConfig), restartedConfig)
+	restarted.SetUIDStore(store)
+	startTestController(t, restarted)
+
+	var deleted []faro.JSONEvent
+	for _, event := range waitForJSONEvents(t, restartedConfig, 2) {
+		if event.EventType == "DELETED" {
+			deleted = append(deleted, event)
+		}
+	}
+	if len(deleted) != 1 || deleted[0].Name != "doomed" || deleted[0].UID != "uid-doomed" {
+		t.Fatalf("expected one DELETED event for doomed with its stored UID, got %+v", deleted)
+	}
+
+	waitFor(t, "stored UID of doomed to be removed", func() bool {
+		_, found, _ := store.Get("v1/configmaps/test-ns/doomed")
+		return !found
+	})
+	if _, found, _ := store.Get("v1/configmaps/test-ns/kept"); !found {
+		t.Error("expected the UID of the surviving object to stay stored")
+	}
+}
+
+func TestConfiguredScopeMismatch(t *testing.T) {
+	resource := faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.ClusterScope, NamespaceNames: []string{"test-ns"}}
+
+	t.Run("warns and uses discovered scope", func(t *testing.T) {
+		client, _ := newFakeClient(newConfigMap("test-ns", "settings", "uid-1", nil))
+		config := newTestConfig(t, resource)
+		controller := faro.NewController(client, newTestLogger(t, config), config)
+		handler := &recordingHandler{}
+		controller.AddEventHandler(handler)
+		startTestController(t, controller)
+
+		handler.waitForStableCount(t)
+		if added := countEvents(handler.Events(), "ADDED"); added != 1 {
+			t.Errorf("expected the namespaced informer to report 1 ADDED event, got %d", added)
+		}
+
+		files, err := filepath.Glob(filepath.Join(config.GetLogDir(), "faro-*.log"))
+		if err != nil || len(files) == 0 {
+			t.Fatalf("No log file found: %v", err)
+		}
+		content, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatalf("Failed to read log file: %v", err)
+		}
+		if !strings.Contains(string(content), "v1/configmaps is configured with scope Cluster but discovery reports Namespaced") {
+			t.Errorf("expected a scope mismatch warning in the log:\n%s", content)
+		}
+	})
+
+	t.Run("fails with FailOnScopeMismatch", func(t *testing.T) {
+		client, _ := newFakeClient()
+		config := newTestConfig(t, resource)
+		config.FailOnScopeMismatch = true
+		controller := faro.NewController(client, newTestLogger(t, config), config)
+		defer controller.Stop()
+
+		err := controller.Start()
+		if err == nil || !strings.Contains(err.Error(), "configured with scope Cluster but discovery reports Namespaced") {
+			t.Fatalf("expected a scope mismatch error from Start, got %v", err)
+		}
+	})
+}
+
+func TestSnapshotAllWritesEveryTrackedObject(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("ns-a", "first", "uid-1", map[string]string{"app": "web"}),
+		newConfigMap("ns-a", "second", "uid-2", nil),
+		newConfigMap("ns-b", "third", "uid-3", nil),
+		newObject("v1", "Secret", "ns-a", "credentials", "uid-4", nil),
+	)
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"ns-a", "ns-b"}},
+		faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"ns-a"}},
+	)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	handler.waitForStableCount(t)
+
+	var buf bytes.Buffer
+	if err := controller.SnapshotAll(&buf); err != nil {
+		t.Fatalf("SnapshotAll failed: %v", err)
+	}
+
+	uids := map[string]string{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event faro.JSONEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid snapshot line %q: %v", scanner.Text(), err)
+		}
+		if event.EventType != "SNAPSHOT" {
+			t.Errorf("expected eventType SNAPSHOT, got %+v", event)
+		}
+		uids[event.UID] = event.GVR + " " + event.Namespace + "/" + event.Name
+		if event.UID == "uid-1" && event.Labels["app"] != "web" {
+			t.Errorf("expected labels in the snapshot, got %+v", event)
+		}
+	}
+	expected := map[string]string{
+		"uid-1": "v1/configmaps ns-a/first",
+		"uid-2": "v1/configmaps ns-a/second",
+		"uid-3": "v1/configmaps ns-b/third",
+		"uid-4": "v1/secrets ns-a/credentials",
+	}
+	if !reflect.DeepEqual(uids, expected) {
+		t.Errorf("expected snapshot %v, got %v", expected, uids)
+	}
+}
+
+func TestIgnoreSelfInducedChangesDropsOwnUpdates(t *testing.T) {
+	cm := newConfigMap("test-ns", "settings", "uid-1", nil)
+	client, dynamicClient := newFakeClient(cm)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.IgnoreSelfInducedChanges = true
+	config.SelfFieldManager = "my-operator"
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "initial ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+
+	created := metav1.NewTime(time.Now().Add(-time.Minute))
+	later := metav1.NewTime(time.Now())
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	update := func(revision, lastManager string) {
+		cm.SetLabels(map[string]string{"revision": revision})
+		cm.SetManagedFields([]metav1.ManagedFieldsEntry{
+			{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate, Time: &created},
+			{Manager: lastManager, Operation: metav1.ManagedFieldsOperationUpdate, Time: &later},
+		})
+		if _, err := configMaps.Update(context.Background(), cm, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("Failed to update ConfigMap: %v", err)
+		}
+	}
+
+	// The handler's own write is dropped, a later change by someone else is not
+	update("self", "my-operator")
+	update("external", "helm")
+
+	waitFor(t, "UPDATED event", func() bool { return countEvents(handler.Events(), "UPDATED") > 0 })
+	handler.waitForStableCount(t)
+	var revisions []string
+	for _, event := range handler.Events() {
+		if event.EventType == "UPDATED" {
+			revisions = append(revisions, event.Object.GetLabels()["revision"])
+		}
+	}
+	if !reflect.DeepEqual(revisions, []string{"external"}) {
+		t.Errorf("expected only the external UPDATED event, got revisions %v", revisions)
+	}
+}
+
+func TestResyncPeriodIsStaggeredPerInformer(t *testing.T) {
+	var namespaces []string
+	for i := 0; i < 10; i++ {
+		namespaces = append(namespaces, fmt.Sprintf("ns-%d", i))
+	}
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: namespaces})
+	config.ResyncPeriodSec = 60
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	statuses := controller.DescribeInformers()
+	if len(statuses) != len(namespaces) {
+		t.Fatalf("expected %d informers, got %d", len(namespaces), len(statuses))
+	}
+	periods := make(map[time.Duration]bool)
+	for _, status := range statuses {
+		if status.ResyncPeriod < 60*time.Second || status.ResyncPeriod >= 66*time.Second {
+			t.Errorf("expected %s resync period within 60s+10%%, got %s", status.Namespace, status.ResyncPeriod)
+		}
+		periods[status.ResyncPeriod] = true
+	}
+	// Identical periods would relist every namespace at the same moment
+	if len(periods) < len(namespaces)-1 {
+		t.Errorf("expected staggered resync periods, got %d distinct for %d informers: %+v", len(periods), len(namespaces), statuses)
+	}
+}
+
+func TestDescribeInformersReportsPerInformerStatus(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("ns-a", "first", "uid-1", nil),
+		newConfigMap("ns-a", "second", "uid-2", nil),
+	)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"ns-a", "ns-b"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startedAt := time.Now()
+	startTestController(t, controller)
+	waitFor(t, "initial ADDED events", func() bool { return countEvents(handler.Events(), "ADDED") == 2 })
+	waitFor(t, "informer sync", controller.Ready)
+
+	statuses := controller.DescribeInformers()
+	if active, _ := controller.GetActiveInformers(); len(statuses) != active {
+		t.Fatalf("expected %d informer statuses, got %+v", active, statuses)
+	}
+	if len(statuses) != 2 || statuses[0].Namespace != "ns-a" || statuses[1].Namespace != "ns-b" {
+		t.Fatalf("expected statuses for ns-a and ns-b, got %+v", statuses)
+	}
+	for _, status := range statuses {
+		if status.GVR != "v1/configmaps" || !status.Synced || status.Health != faro.InformerHealthHealthy {
+			t.Errorf("expected a synced, healthy v1/configmaps informer, got %+v", status)
+		}
+	}
+	if statuses[0].TrackedCount != 2 || statuses[0].LastEventTime == nil || statuses[0].LastEventTime.Before(startedAt) {
+		t.Errorf("expected ns-a to track 2 objects with a recent last event, got %+v", statuses[0])
+	}
+	if statuses[1].TrackedCount != 0 || statuses[1].LastEventTime != nil {
+		t.Errorf("expected empty ns-b to have no objects and no events, got %+v", statuses[1])
+	}
+
+	// An informer without events leaves the last event time out of its JSON
+	encoded, err := json.Marshal(statuses[1])
+	if err != nil {
+		t.Fatalf("Failed to marshal informer status: %v", err)
+	}
+	if strings.Contains(string(encoded), "lastEventTime") {
+		t.Errorf("expected no lastEventTime for ns-b, got %s", encoded)
+	}
+}
+
+func TestDedupAcrossResyncSuppressesReplayedAdds(t *testing.T) {
+	unchanged := newConfigMap("test-ns", "unchanged", "uid-1", nil)
+	changed := newConfigMap("test-ns", "changed", "uid-2", nil)
+	unchanged.SetResourceVersion("1")
+	changed.SetResourceVersion("1")
+	client, dynamicClient := newFakeClient(unchanged, changed)
+
+	// Failing watches open the circuit breaker, which stops the informer after its initial list
+	var failWatches atomic.Bool
+	failWatches.Store(true)
+	dynamicClient.PrependWatchReactor("configmaps", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		if failWatches.Load() {
+			return true, nil, errors.New("simulated watch failure")
+		}
+		return false, nil, nil
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.DedupAcrossResync = true
+	config.MaxInformerRestarts = 1
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	failed := make(chan struct{}, 1)
+	controller.SetInformerFailedCallback(func(gvrString, namespace string, err error) {
+		failed <- struct{}{}
+	})
+	startTestController(t, controller)
+
+	select {
+	case <-failed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the informer to stop")
+	}
+	waitFor(t, "informer to stop", func() bool {
+		active, _ := controller.GetActiveInformers()
+		return active == 0
+	})
+
+	// One object changes while the informer is down
+	changed.SetResourceVersion("2")
+	changed.SetLabels(map[string]string{"revision": "2"})
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if _, err := configMaps.Update(context.Background(), changed, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+
+	handler.waitForStableCount(t)
+	if added := countEvents(handler.Events(), "ADDED"); added != 2 {
+		t.Fatalf("expected 2 ADDED events before the restart, got %d", added)
+	}
+	// Relists after the watch failures may already have reported UPDATED events
+	before := len(handler.Events())
+
+	failWatches.Store(false)
+	if err := controller.StartInformers(); err != nil {
+		t.Fatalf("Failed to restart informers: %v", err)
+	}
+	waitFor(t, "UPDATED event after restart", func() bool { return len(handler.Events()) > before })
+	handler.waitForStableCount(t)
+
+	replayed := handler.Events()[before:]
+	if len(replayed) != 1 || replayed[0].EventType != "UPDATED" || replayed[0].Object.GetName() != "changed" {
+		for _, event := range replayed {
+			t.Logf("event after restart: %s %s", event.EventType, event.Object.GetName())
+		}
+		t.Errorf("expected only an UPDATED event for the changed object after the restart, got %d events", len(replayed))
+	}
+}
+
+// orderMiddleware appends its name to a shared log for every event it processes
+type orderMiddleware struct {
+	name string
+	mu   *sync.Mutex
+	log  *[]string
+}
+
+func (m orderMiddleware) ProcessBeforeJSON(eventType, gvr, namespace, name, uid string, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool) {
+	m.mu.Lock()
+	*m.log = append(*m.log, m.name+" "+gvr)
+	m.mu.Unlock()
+	return obj, true
+}
+
+func TestJSONMiddlewareForGVROnlyRunsForItsGVR(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("test-ns", "settings", "uid-1", nil),
+		newObject("v1", "Secret", "test-ns", "credentials", "uid-2", nil),
+	)
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}},
+		faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}},
+	)
+	config.JsonExport = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+
+	var mu sync.Mutex
+	var calls []string
+	controller.AddJSONMiddleware(orderMiddleware{name: "generic", mu: &mu, log: &calls})
+	controller.AddJSONMiddlewareForGVR("v1/secrets", orderMiddleware{name: "secrets", mu: &mu, log: &calls})
+	startTestController(t, controller)
+
+	waitForJSONEvents(t, config, 2)
+	mu.Lock()
+	defer mu.Unlock()
+	sort.SliceStable(calls, func(i, j int) bool {
+		return strings.HasSuffix(calls[i], "configmaps") && !strings.HasSuffix(calls[j], "configmaps")
+	})
+	expected := []string{"generic v1/configmaps", "secrets v1/secrets", "generic v1/secrets"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected middleware calls %v, got %v", expected, calls)
+	}
+}
+
+func TestPauseDropsEventsUntilResume(t *testing.T) {
+	client, dynamicClient := newFakeClient(newConfigMap("test-ns", "before", "uid-1", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	baseURL := enableTestMetrics(t, config)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "initial ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+
+	controller.Pause()
+	waitFor(t, "paused gauge", func() bool {
+		_, body := httpGet(t, baseURL+"/metrics")
+		return strings.Contains(body, "faro_controller_paused 1")
+	})
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if _, err := configMaps.Create(context.Background(), newConfigMap("test-ns", "during", "uid-2", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	waitFor(t, "paused object in the informer cache", func() bool {
+		_, err := controller.GetObject("v1/configmaps", "test-ns", "during")
+		return err == nil
+	})
+	time.Sleep(200 * time.Millisecond)
+	if events := handler.Events(); len(events) != 1 {
+		t.Fatalf("expected no events while paused, got %d events", len(events))
+	}
+
+	controller.Resume()
+	if _, err := configMaps.Create(context.Background(), newConfigMap("test-ns", "after", "uid-3", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	waitFor(t, "ADDED event after resume", func() bool { return countEvents(handler.Events(), "ADDED") == 2 })
+	handler.waitForStableCount(t)
+	for _, event := range handler.Events() {
+		if event.Object.GetName() == "during" {
+			t.Errorf("expected the event observed while paused to be dropped, got %s", event.EventType)
+		}
+	}
+	if _, body := httpGet(t, baseURL+"/metrics"); !strings.Contains(body, "faro_controller_paused 0") {
+		t.Error("expected faro_controller_paused 0 after Resume")
+	}
+}
+
+// failingCoreDiscovery fails discovery of the core ("v1") group
+type failingCoreDiscovery struct {
+	*discoveryfake.FakeDiscovery
+}
+
+func (d *failingCoreDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	if groupVersion == "v1" {
+		return nil, fmt.Errorf("core discovery unavailable")
+	}
+	return d.FakeDiscovery.ServerResourcesForGroupVersion(groupVersion)
+}
+
+func TestDiscoveryRetriesSurviveUnavailableAPIServer(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		retries  int
+		wantFail bool
+	}{
+		{name: "no retries", retries: 0, wantFail: true},
+		{name: "retries", retries: 3, wantFail: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, _ := newFakeClient()
+			var calls atomic.Int32
+			client.Discovery.(*discoveryfake.FakeDiscovery).PrependReactor("get", "group", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if calls.Add(1) <= 2 {
+					return true, nil, errors.New("connection refused")
+				}
+				return false, nil, nil
+			})
+
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope})
+			config.DiscoveryRetries = tc.retries
+			config.DiscoveryRetryBackoffMs = 10
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			defer controller.Stop()
+
+			err := controller.Start()
+			if tc.wantFail {
+				if err == nil || calls.Load() != 1 {
+					t.Fatalf("expected Start to fail after one discovery attempt, got err %v after %d attempts", err, calls.Load())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected Start to succeed once discovery recovers, got: %v", err)
+			}
+			if got := calls.Load(); got != 3 {
+				t.Errorf("expected 3 discovery attempts, got %d", got)
+			}
+		})
+	}
+}
+
+func TestRequireCoreGroupFailsStartOnCoreDiscoveryError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		require  *bool
+		wantFail bool
+	}{
+		{name: "default", require: nil, wantFail: true},
+		{name: "required", require: &[]bool{true}[0], wantFail: true},
+		{name: "optional", require: &[]bool{false}[0], wantFail: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, _ := newFakeClient()
+			client.Discovery = &failingCoreDiscovery{
+				FakeDiscovery: &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: fakeAPIResources}},
+			}
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope})
+			config.RequireCoreGroup = tc.require
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			defer controller.Stop()
+
+			err := controller.Start()
+			if tc.wantFail {
+				if err == nil || !strings.Contains(err.Error(), "core API group") {
+					t.Fatalf("expected Start to fail on core group discovery, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected Start to succeed with require_core_group disabled, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigIDPropagatesToMatchedAndJSONEvents(t *testing.T) {
+	client, dynamicClient := newFakeClient(
+		newConfigMap("team-a", "settings", "uid-a", nil),
+		newConfigMap("team-b", "settings", "uid-b", nil),
+	)
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"team-a"}, ConfigID: "config-a"},
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"team-b"}, ConfigID: "config-b"},
+	)
+	config.JsonExport = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "initial ADDED events", func() bool { return countEvents(handler.Events(), "ADDED") == 2 })
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("team-b")
+	if err := configMaps.Delete(context.Background(), "settings", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+	waitFor(t, "DELETED event", func() bool { return countEvents(handler.Events(), "DELETED") > 0 })
+
+	wantID := map[string]string{"team-a": "config-a", "team-b": "config-b"}
+	for _, event := range handler.Events() {
+		if event.EventType == "DELETED" {
+			continue // Delivered once per config of the informer
+		}
+		if want := wantID[event.Object.GetNamespace()]; event.Config.ConfigID != want {
+			t.Errorf("expected %s event for %s to carry config ID %q, got %q", event.EventType, event.Key, want, event.Config.ConfigID)
+		}
+	}
+
+	for _, event := range waitForJSONEvents(t, config, 3) {
+		if want := wantID[event.Namespace]; event.ConfigID != want {
+			t.Errorf("expected JSON %s event for %s/%s to carry configId %q, got %q", event.EventType, event.Namespace, event.Name, want, event.ConfigID)
+		}
+	}
+}
+
+func TestMaxReconcileRetriesDropsPoisonItem(t *testing.T) {
+	// Handler errors never fail a work item, but a DELETED event without a UID (the object was
+	// created without one) fails reconcile on every attempt
+	client, dynamicClient := newFakeClient(newConfigMap("test-ns", "broken", "", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.MaxReconcileRetries = 3
+	enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "initial ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if err := configMaps.Delete(context.Background(), "broken", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+	waitFor(t, "work item dropped", func() bool { return controller.Metrics().DroppedWorkItemCount("v1/configmaps") == 1 })
+
+	var content []byte
+	waitFor(t, "drop logged", func() bool {
+		files, _ := filepath.Glob(filepath.Join(config.GetLogDir(), "faro-*.log"))
+		if len(files) == 0 {
+			return false
+		}
+		content, _ = os.ReadFile(files[0])
+		return strings.Contains(string(content), "Dropping DELETED event for v1/configmaps test-ns/broken after 3 retries")
+	})
+	// klog may write an error line to the file once per severity, so compare against the drop line
+	failures := strings.Count(string(content), "Error processing test-ns/broken")
+	drops := strings.Count(string(content), "Dropping DELETED event for v1/configmaps test-ns/broken")
+	if failures != 3*drops {
+		t.Errorf("expected 3 failures re-queued before the drop, got %d failure and %d drop lines", failures, drops)
+	}
+
+	// The dropped item is not retried again
+	time.Sleep(200 * time.Millisecond)
+	if got := controller.Metrics().DroppedWorkItemCount("v1/configmaps"); got != 1 {
+		t.Errorf("expected exactly 1 dropped work item, got %v", got)
+	}
+	if countEvents(handler.Events(), "DELETED") != 0 {
+		t.Errorf("expected no DELETED event for the dropped item")
+	}
+}
+
+func TestStopInformerStopsOnlyThatInformer(t *testing.T) {
+	client, dynamicClient := newFakeClient()
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}},
+		faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}},
+	)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	if err := controller.StopInformer("v1/secrets", "test-ns"); err != nil {
+		t.Fatalf("Failed to stop the secrets informer: %v", err)
+	}
+	waitFor(t, "secrets informer to exit", func() bool {
+		active, _ := controller.GetActiveInformers()
+		return active == 1
+	})
+	if err := controller.StopInformer("v1/secrets", "test-ns"); err == nil {
+		t.Error("expected stopping an informer that is not running to fail")
+	}
+
+	secrets := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}).Namespace("test-ns")
+	if _, err := secrets.Create(context.Background(), newObject("v1", "Secret", "test-ns", "token", "uid-s", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Secret: %v", err)
+	}
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if _, err := configMaps.Create(context.Background(), newConfigMap("test-ns", "settings", "uid-c", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	waitFor(t, "ConfigMap ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") > 0 })
+	handler.waitForStableCount(t)
+
+	for _, event := range handler.Events() {
+		if event.GVR == "v1/secrets" {
+			t.Errorf("expected no events from the stopped secrets informer, got %s %s", event.EventType, event.Key)
+		}
+	}
+	if statuses := controller.DescribeInformers(); len(statuses) != 1 || statuses[0].GVR != "v1/configmaps" {
+		t.Errorf("expected only the configmaps informer to keep running, got %+v", statuses)
+	}
+}
+
+func TestActiveInformerCountsTrackRunningInformers(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"ns-a", "ns-b"}},
+		faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"ns-a"}},
+	)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	waitFor(t, "3 running informers", func() bool {
+		configured, running := controller.GetActiveInformers()
+		return configured == 3 && running == 3
+	})
+
+	if err := controller.StopInformer("v1/configmaps", "ns-b"); err != nil {
+		t.Fatalf("Failed to stop informer: %v", err)
+	}
+	waitFor(t, "2 running informers", func() bool {
+		configured, running := controller.GetActiveInformers()
+		return configured == 2 && running == 2
+	})
+
+	// Stopped informers can be started again
+	if err := controller.StartInformers(); err != nil {
+		t.Fatalf("Failed to restart informers: %v", err)
+	}
+	waitFor(t, "3 running informers after restart", func() bool {
+		configured, running := controller.GetActiveInformers()
+		return configured == 3 && running == 3
+	})
+}
+
+// blockingHandler records events but blocks in OnMatched until released
+type blockingHandler struct {
+	recordingHandler
+	release chan struct{}
+}
+
+func (b *blockingHandler) Name() string { return "blocked-sink" }
+
+func (b *blockingHandler) OnMatched(event faro.MatchedEvent) error {
+	<-b.release
+	return b.recordingHandler.OnMatched(event)
+}
+
+func TestHandlerQueueDropsForBlockedConsumer(t *testing.T) {
+	const objects = 50
+
+	client, dynamicClient := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.HandlerQueueSize = 2
+	enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &blockingHandler{release: make(chan struct{})}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+	baseline := goruntime.NumGoroutine()
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	for i := 0; i < objects; i++ {
+		cm := newConfigMap("test-ns", fmt.Sprintf("cm-%d", i), fmt.Sprintf("uid-%d", i), nil)
+		if _, err := configMaps.Create(context.Background(), cm, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Failed to create ConfigMap: %v", err)
+		}
+	}
+
+	// One event is in OnMatched and at most two are buffered, the rest is dropped
+	metrics := controller.Metrics()
+	waitFor(t, "events dropped for the blocked handler", func() bool {
+		return metrics.DroppedDeliveryCount("blocked-sink") >= objects-3
+	})
+	if grown := goruntime.NumGoroutine() - baseline; grown > 10 {
+		t.Errorf("expected goroutines to stay bounded with a blocked handler, %d were added", grown)
+	}
+
+	// Stop delivers what was buffered
+	close(handler.release)
+	controller.Stop()
+	dropped := int(metrics.DroppedDeliveryCount("blocked-sink"))
+	if received := len(handler.Events()); received+dropped != objects || received == 0 {
+		t.Errorf("expected every event to be either delivered or dropped: %d delivered, %d dropped", received, dropped)
+	}
+}
+
+func TestPreferredVersionResolvesFromDiscovery(t *testing.T) {
+	widgetsV1 := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	widgetsV2 := schema.GroupVersionResource{Group: "example.com", Version: "v2", Resource: "widgets"}
+	gadgetsV1 := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "gadgets"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{widgetsV1: "WidgetList", widgetsV2: "WidgetList", gadgetsV1: "GadgetList"},
+		newObject("example.com/v2", "Widget", "test-ns", "widget", "uid-widget", nil),
+		newObject("example.com/v1", "Gadget", "test-ns", "gadget", "uid-gadget", nil),
+	)
+	// The fake discovery reports the first listed version of a group as preferred: v2 serves
+	// widgets, gadgets are only served by v1
+	client := &faro.KubernetesClient{
+		Dynamic: dynamicClient,
+		Discovery: &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: append([]*metav1.APIResourceList{
+			{GroupVersion: "example.com/v2", APIResources: []metav1.APIResource{
+				{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: []string{"list", "watch"}},
+			}},
+			{GroupVersion: "example.com/v1", APIResources: []metav1.APIResource{
+				{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: []string{"list", "watch"}},
+				{Name: "gadgets", Kind: "Gadget", Namespaced: true, Verbs: []string{"list", "watch"}},
+			}},
+		}, fakeAPIResources...)}},
+	}
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "example.com//widgets", NamespaceNames: []string{"test-ns"}, PreferredVersion: true},
+		faro.ResourceConfig{GVR: "example.com/v1beta1/gadgets", NamespaceNames: []string{"test-ns"}, PreferredVersion: true},
+	)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	if count := handler.waitForStableCount(t); count != 2 {
+		t.Fatalf("expected one ADDED event per resource, got %d: %+v", count, handler.Events())
+	}
+	gvrs := map[string]string{}
+	for _, event := range handler.Events() {
+		gvrs[event.Object.GetName()] = event.GVR
+		if event.Config.GVR != event.GVR {
+			t.Errorf("expected the matched config to carry the resolved GVR %s, got %s", event.GVR, event.Config.GVR)
+		}
+	}
+	if gvrs["widget"] != "example.com/v2/widgets" {
+		t.Errorf("expected widgets to resolve to the preferred version v2, got %q", gvrs["widget"])
+	}
+	if gvrs["gadget"] != "example.com/v1/gadgets" {
+		t.Errorf("expected gadgets to resolve to the only version serving them, got %q", gvrs["gadget"])
+	}
+}
+
+func TestAddEventHandlerForGVRReceivesOnlyItsResource(t *testing.T) {
+	client, dynamicClient := newFakeClient()
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}},
+		faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}},
+	)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	global := &recordingHandler{}
+	secretsOnly := &recordingHandler{}
+	controller.AddEventHandler(global)
+	controller.AddEventHandlerForGVR("v1/secrets", secretsOnly)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	cm := newConfigMap("test-ns", "cm", "uid-cm", nil)
+	if _, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns").Create(context.Background(), cm, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	secret := newObject("v1", "Secret", "test-ns", "secret", "uid-secret", nil)
+	if _, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}).Namespace("test-ns").Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Secret: %v", err)
+	}
+
+	waitFor(t, "global handler to see both resources", func() bool { return len(global.Events()) >= 2 })
+	if count := secretsOnly.waitForStableCount(t); count != 1 {
+		t.Fatalf("expected the scoped handler to receive 1 event, got %d", count)
+	}
+	if gvr := secretsOnly.Events()[0].GVR; gvr != "v1/secrets" {
+		t.Errorf("expected the scoped handler to only receive v1/secrets events, got %s", gvr)
+	}
+}
+
+func TestMatchedEventCarriesStructuredGVR(t *testing.T) {
+	pod := newObject("v1", "Pod", "default", "web-abc-1", "uid-pod", nil)
+	deployment := newObject("apps/v1", "Deployment", "default", "web", "uid-deploy", nil)
+	client, _ := newWorkloadFakeClient(pod, deployment)
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/pods", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}},
+		faro.ResourceConfig{GVR: "apps/v1/deployments", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}},
+	)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "events for both resources", func() bool { return len(handler.Events()) >= 2 })
+	expected := map[string]struct {
+		resource schema.GroupVersionResource
+		kind     string
+	}{
+		"v1/pods":             {schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "Pod"},
+		"apps/v1/deployments": {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, "Deployment"},
+	}
+	for _, event := range handler.Events() {
+		want, ok := expected[event.GVR]
+		if !ok {
+			t.Fatalf("unexpected event for %s", event.GVR)
+		}
+		if event.Resource != want.resource {
+			t.Errorf("expected %s to parse as %+v, got %+v", event.GVR, want.resource, event.Resource)
+		}
+		if event.Kind != want.kind {
+			t.Errorf("expected %s Kind %q, got %q", event.GVR, want.kind, event.Kind)
+		}
+	}
+}
+
+func TestJSONErrorExportRecordsWatchErrors(t *testing.T) {
+	client, dynamicClient := newFakeClient()
+	dynamicClient.PrependWatchReactor("configmaps", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, nil, errors.New("simulated watch failure")
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.JsonErrorExport = true
+	config.MaxInformerRestarts = 5
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	var record faro.ErrorRecord
+	waitFor(t, "watch error record", func() bool {
+		files, _ := filepath.Glob(filepath.Join(config.GetLogDir(), "errors-*.json"))
+		for _, path := range files {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+				if err := json.Unmarshal([]byte(line), &record); err == nil && record.Type == "watch_error" {
+					return true
+				}
+			}
+		}
+		return false
+	})
+
+	if record.Level != "warning" || record.Component != "controller" {
+		t.Errorf("expected a controller warning record, got %+v", record)
+	}
+	if record.Fields["gvr"] != "v1/configmaps" || record.Fields["namespace"] != "test-ns" {
+		t.Errorf("expected gvr and namespace fields, got %v", record.Fields)
+	}
+	if !strings.Contains(record.Fields["error"], "simulated watch failure") {
+		t.Errorf("expected the watch error in the record, got %q", record.Fields["error"])
+	}
+}
+
+func TestNamespaceLabelSelectorFollowsLabeledNamespaces(t *testing.T) {
+	watched := newObject("v1", "Namespace", "", "team-a", "uid-ns-a", map[string]string{"faro": "watch"})
+	unwatched := newObject("v1", "Namespace", "", "team-b", "uid-ns-b", nil)
+	client, dynamicClient := newFakeClient(watched, unwatched,
+		newConfigMap("team-a", "cm-a", "uid-a", nil),
+		newConfigMap("team-b", "cm-b", "uid-b", nil),
+	)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceLabelSelector: "faro=watch"})
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	hasEvent := func(key string) bool {
+		for _, event := range handler.Events() {
+			if event.Key == key {
+				return true
+			}
+		}
+		return false
+	}
+	waitFor(t, "ConfigMap in the labeled namespace", func() bool { return hasEvent("team-a/cm-a") })
+	if hasEvent("team-b/cm-b") {
+		t.Fatal("expected no events from the unlabeled namespace")
+	}
+
+	// Labeling a namespace starts watching it
+	namespaces := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"})
+	unwatched.SetLabels(map[string]string{"faro": "watch"})
+	if _, err := namespaces.Update(context.Background(), unwatched, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to label namespace: %v", err)
+	}
+	waitFor(t, "ConfigMap in the newly labeled namespace", func() bool { return hasEvent("team-b/cm-b") })
+
+	// Deleting a namespace stops its informer
+	if err := namespaces.Delete(context.Background(), "team-a", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete namespace: %v", err)
+	}
+	waitFor(t, "informer of the deleted namespace to stop", func() bool {
+		_, running := controller.GetActiveInformers()
+		return running == 1
+	})
+
+	// Unlabeling and relabeling in quick succession restarts the namespace's informer
+	unwatched.SetLabels(nil)
+	if _, err := namespaces.Update(context.Background(), unwatched, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to unlabel namespace: %v", err)
+	}
+	unwatched.SetLabels(map[string]string{"faro": "watch"})
+	if _, err := namespaces.Update(context.Background(), unwatched, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to relabel namespace: %v", err)
+	}
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("team-b")
+	if _, err := configMaps.Create(context.Background(), newConfigMap("team-b", "cm-b2", "uid-b2", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	waitFor(t, "ConfigMap created after the namespace was relabeled", func() bool { return hasEvent("team-b/cm-b2") })
+}
+
+func TestNamespaceLabelSelectorLeavesOtherConfigsInformers(t *testing.T) {
+	labeled := newObject("v1", "Namespace", "", "team-b", "uid-ns-b", map[string]string{"faro": "watch"})
+	client, dynamicClient := newFakeClient(labeled)
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"team-b"}},
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceLabelSelector: "faro=watch"},
+	)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	// The namespace stops matching, but team-b is also watched by name
+	namespaces := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"})
+	labeled.SetLabels(nil)
+	if _, err := namespaces.Update(context.Background(), labeled, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to unlabel namespace: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if _, running := controller.GetActiveInformers(); running != 1 {
+		t.Fatalf("expected the informer of the name-selected config to keep running, got %d running", running)
+	}
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("team-b")
+	if _, err := configMaps.Create(context.Background(), newConfigMap("team-b", "cm-b", "uid-b", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	waitFor(t, "ConfigMap ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+}
+
+func TestMaxHandlerConcurrencyBoundsHandlerGoroutines(t *testing.T) {
+	const objects = 80
+	const slots = 5
+
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{name: "drop", policy: faro.HandlerConcurrencyPolicyDrop},
+		{name: "block", policy: faro.HandlerConcurrencyPolicyBlock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, dynamicClient := newFakeClient()
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+			config.MaxHandlerConcurrency = slots
+			config.HandlerConcurrencyPolicy = tt.policy
+			enableTestMetrics(t, config)
+
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			handler := &blockingHandler{release: make(chan struct{})}
+			controller.AddEventHandler(handler)
+			startTestController(t, controller)
+			waitFor(t, "informer sync", controller.Ready)
+			baseline := goruntime.NumGoroutine()
+
+			configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+			for i := 0; i < objects; i++ {
+				cm := newConfigMap("test-ns", fmt.Sprintf("cm-%d", i), fmt.Sprintf("uid-%d", i), nil)
+				if _, err := configMaps.Create(context.Background(), cm, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Failed to create ConfigMap: %v", err)
+				}
+			}
+
+			// Every slot ends up held by a blocked handler call
+			metrics := controller.Metrics()
+			if tt.policy == faro.HandlerConcurrencyPolicyDrop {
+				waitFor(t, "events dropped for the blocked handler", func() bool {
+					return metrics.HandlerConcurrencyDroppedCount("blocked-sink") >= objects-slots
+				})
+			} else {
+				time.Sleep(500 * time.Millisecond)
+			}
+			if grown := goruntime.NumGoroutine() - baseline; grown > slots+10 {
+				t.Errorf("expected handler goroutines to stay bounded by %d slots, %d goroutines were added", slots, grown)
+			}
+
+			close(handler.release)
+			dropped := int(metrics.HandlerConcurrencyDroppedCount("blocked-sink"))
+			if tt.policy == faro.HandlerConcurrencyPolicyBlock && dropped != 0 {
+				t.Errorf("expected no drops with the block policy, got %d", dropped)
+			}
+			waitFor(t, "every event delivered or dropped", func() bool {
+				return len(handler.Events())+dropped == objects
+			})
+		})
+	}
+}
+
+func TestStopDeliversEventsWaitingForHandlerSlot(t *testing.T) {
+	const objects = 10
+
+	var existing []runtime.Object
+	for i := 0; i < objects; i++ {
+		existing = append(existing, newConfigMap("test-ns", fmt.Sprintf("cm-%d", i), fmt.Sprintf("uid-%d", i), nil))
+	}
+	client, _ := newFakeClient(existing...)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.MaxHandlerConcurrency = 1
+	config.HandlerConcurrencyPolicy = faro.HandlerConcurrencyPolicyBlock
+	enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &blockingHandler{release: make(chan struct{})}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	// One event holds the only slot, the workers wait for it with the rest still queued
+	stopped := make(chan struct{})
+	go func() {
+		controller.Stop()
+		close(stopped)
+	}()
+	waitFor(t, "shutdown to begin", func() bool { return !controller.Healthy() })
+	time.Sleep(100 * time.Millisecond)
+	close(handler.release)
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop did not return")
+	}
+
+	if dropped := controller.Metrics().HandlerConcurrencyDroppedCount("blocked-sink"); dropped != 0 {
+		t.Errorf("expected no drops with the block policy, got %v", dropped)
+	}
+	if delivered := len(handler.Events()); delivered != objects {
+		t.Errorf("expected all %d events delivered during Stop, got %d", objects, delivered)
+	}
+}
+
+func TestTransformTrimsCachedObjects(t *testing.T) {
+	cm := newConfigMap("test-ns", "large", "uid-1", map[string]string{"app": "web"})
+	cm.Object["data"] = map[string]interface{}{"payload": strings.Repeat("x", 1<<16)}
+	client, _ := newFakeClient(cm)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	controller.SetTransformForGVR("v1/configmaps", faro.DropFields("data", "metadata.managedFields"))
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	cached, err := controller.GetObject("v1/configmaps", "test-ns", "large")
+	if err != nil {
+		t.Fatalf("GetObject failed: %v", err)
+	}
+	if _, found := cached.Object["data"]; found {
+		t.Error("expected the cached object to be stored without data")
+	}
+	if cached.GetLabels()["app"] != "web" {
+		t.Errorf("expected untouched fields to be cached, got labels %v", cached.GetLabels())
+	}
+
+	waitFor(t, "ADDED event", func() bool { return len(handler.Events()) > 0 })
+	if _, found := handler.Events()[0].Object.Object["data"]; found {
+		t.Error("expected handlers to receive the transformed object")
+	}
+}
+
+func TestIsTrackedFollowsObjectLifecycle(t *testing.T) {
+	client, dynamicClient := newFakeClient(newConfigMap("test-ns", "tracked", "uid-tracked", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	if uid, ok := controller.IsTracked("v1/configmaps", "test-ns", "tracked"); !ok || uid != "uid-tracked" {
+		t.Fatalf("expected the existing object to be tracked with its UID, got %q, %v", uid, ok)
+	}
+	if _, ok := controller.IsTracked("v1/configmaps", "test-ns", "missing"); ok {
+		t.Error("expected an unknown object not to be tracked")
+	}
+	if _, ok := controller.IsTracked("v1/configmaps", "other-ns", "tracked"); ok {
+		t.Error("expected an object of an unwatched namespace not to be tracked")
+	}
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if err := configMaps.Delete(context.Background(), "tracked", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+	waitFor(t, "DELETED event", func() bool { return countEvents(handler.Events(), "DELETED") == 1 })
+	if uid, ok := controller.IsTracked("v1/configmaps", "test-ns", "tracked"); ok {
+		t.Errorf("expected the deleted object to no longer be tracked, got UID %q", uid)
+	}
+}
+
+func TestDeleteUIDPolicyEmitsDeletesWithoutCachedUID(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		objectUID   string // UID of the evicted object itself
+		expectedUID string // "" = the DELETED event is dropped
+	}{
+		{name: "require uses the object's UID", policy: "", objectUID: "uid-evicted", expectedUID: "uid-evicted"},
+		{name: "unknown uses the object's UID", policy: faro.DeleteUIDPolicyUnknown, objectUID: "uid-evicted", expectedUID: "uid-evicted"},
+		{name: "best-effort uses the object's UID", policy: faro.DeleteUIDPolicyBestEffort, objectUID: "uid-evicted", expectedUID: "uid-evicted"},
+		{name: "require drops without a UID", policy: "", expectedUID: ""},
+		{name: "unknown emits without a UID", policy: faro.DeleteUIDPolicyUnknown, expectedUID: "unknown"},
+		{name: "best-effort emits without a UID", policy: faro.DeleteUIDPolicyBestEffort, expectedUID: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The one-entry UID cache evicts the first object's UID when the second is added
+			client, dynamicClient := newFakeClient(newConfigMap("test-ns", "evicted", tt.objectUID, nil))
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+			config.JsonExport = true
+			config.UIDCacheMaxEntries = 1
+			config.DeleteUIDPolicy = tt.policy
+
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			startTestController(t, controller)
+			waitFor(t, "informer sync", controller.Ready)
+
+			configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+			if _, err := configMaps.Create(context.Background(), newConfigMap("test-ns", "cached", "uid-cached", nil), metav1.CreateOptions{}); err != nil {
+				t.Fatalf("Failed to create ConfigMap: %v", err)
+			}
+			waitFor(t, "UID of the first object to be evicted", func() bool {
+				_, tracked := controller.IsTracked("v1/configmaps", "test-ns", "evicted")
+				return !tracked
+			})
+
+			// The informer drops the evicted object's DELETED event before it sees the cached
+			// object's, so under "require" the latter marks the end of processing
+			for _, name := range []string{"evicted", "cached"} {
+				if err := configMaps.Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil {
+					t.Fatalf("Failed to delete ConfigMap %s: %v", name, err)
+				}
+			}
+			var deleted map[string]string // name -> uid
+			waitFor(t, "DELETED event of the cached object", func() bool {
+				deleted = make(map[string]string)
+				for _, event := range readJSONEvents(t, config) {
+					if event.EventType == "DELETED" {
+						deleted[event.Name] = event.UID
+					}
+				}
+				_, evictedEmitted := deleted["evicted"]
+				return deleted["cached"] == "uid-cached" && (tt.expectedUID == "" || evictedEmitted)
+			})
+
+			uid, emitted := deleted["evicted"]
+			if tt.expectedUID == "" && emitted {
+				t.Errorf("expected the DELETED event without a UID to be dropped, got UID %q", uid)
+			}
+			if tt.expectedUID != "" && uid != tt.expectedUID {
+				t.Errorf("expected the DELETED event to be emitted with UID %q, got %q (emitted: %v)", tt.expectedUID, uid, emitted)
+			}
+		})
+	}
+}
+
+func TestLogPrefixTagsSharedLoggerOutput(t *testing.T) {
+	clientA, _ := newFakeClient(newConfigMap("test-ns", "cm-a", "uid-a", nil))
+	clientB, _ := newFakeClient(newConfigMap("test-ns", "cm-b", "uid-b", nil))
+	configA := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	configA.JsonExport = true
+	configA.LogPrefix = "cluster-a"
+	configB := *configA
+	configB.LogPrefix = "cluster-b"
+
+	// Both controllers write through one logger, as per-cluster controllers of one process do
+	logger := newTestLogger(t, configA)
+	startTestController(t, faro.NewController(clientA, logger, configA))
+	startTestController(t, faro.NewController(clientB, logger, &configB))
+
+	sources := make(map[string]string)
+	for _, event := range waitForJSONEvents(t, configA, 2) {
+		sources[event.Name] = event.Source
+	}
+	if sources["cm-a"] != "cluster-a" || sources["cm-b"] != "cluster-b" {
+		t.Errorf("expected each event tagged with its controller's prefix, got %v", sources)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(configA.GetLogDir(), "faro-*.log"))
+	if len(files) != 1 {
+		t.Fatalf("expected one log file, got %v", files)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	for _, tag := range []string{"[cluster-a] [controller]", "[cluster-b] [controller]"} {
+		if !strings.Contains(string(content), tag) {
+			t.Errorf("expected log lines tagged %q, got:\n%s", tag, content)
+		}
+	}
+}
+
+func TestJsonPartitionBySplitsExportByLabel(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("test-ns", "cm-a", "uid-a", map[string]string{"tenant": "alpha"}),
+		newConfigMap("test-ns", "cm-b", "uid-b", map[string]string{"tenant": "beta"}),
+		newConfigMap("test-ns", "cm-none", "uid-none", nil),
+	)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.JsonExport = true
+	config.JsonPartitionBy = "tenant"
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+	waitForJSONEvents(t, config, 3)
+
+	// namesIn returns the names of the events in the export files matching pattern
+	namesIn := func(pattern string) []string {
+		files, _ := filepath.Glob(filepath.Join(config.GetLogDir(), pattern))
+		var names []string
+		for _, path := range files {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", path, err)
+			}
+			for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+				var event faro.JSONEvent
+				if err := json.Unmarshal([]byte(line), &event); err == nil {
+					names = append(names, event.Name)
+				}
+			}
+		}
+		return names
+	}
+	for pattern, want := range map[string][]string{
+		"events-alpha-*.json": {"cm-a"},
+		"events-beta-*.json":  {"cm-b"},
+		"events-[0-9]*.json":  {"cm-none"},
+	} {
+		if got := namesIn(pattern); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %s to hold %v, got %v", pattern, want, got)
+		}
+	}
+}
+
+func TestPendingWorkItemsDrainsToZero(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("test-ns", "cm-1", "uid-1", nil),
+		newConfigMap("test-ns", "cm-2", "uid-2", nil),
+		newConfigMap("test-ns", "cm-3", "uid-3", nil),
+	)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.JsonExport = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	// Hold every JSON export so the events stay in flight
+	gate := &blockingMiddleware{entered: make(chan struct{}), release: make(chan struct{})}
+	controller.AddJSONMiddleware(gate)
+	startTestController(t, controller)
+
+	waitFor(t, "all ADDED events pending", func() bool { return controller.PendingWorkItems() == 3 })
+	if events := readJSONEvents(t, config); len(events) != 0 {
+		t.Fatalf("expected no exported events while workers are held, got %d", len(events))
+	}
+
+	close(gate.release)
+	waitFor(t, "work queue to drain", func() bool { return controller.PendingWorkItems() == 0 })
+
+	// No sleep: a drained queue means every event has been exported
+	if events := readJSONEvents(t, config); len(events) != 3 {
+		t.Errorf("expected 3 exported events once drained, got %d: %+v", len(events), events)
+	}
+}
+
+func TestObjectWithoutKeyIsQueuedByUID(t *testing.T) {
+	client, dynamicClient := newFakeClient()
+	watcher := watch.NewFakeWithChanSize(10, false)
+	dynamicClient.PrependWatchReactor("configmaps", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, watcher, nil
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	enableTestMetrics(t, config)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	// A malformed object without a name has no namespace/name key
+	watcher.Add(newConfigMap("test-ns", "", "uid-nameless", nil))
+
+	waitFor(t, "event for the nameless object", func() bool { return len(handler.Events()) == 1 })
+	event := handler.Events()[0]
+	if event.EventType != "ADDED" || string(event.Object.GetUID()) != "uid-nameless" {
+		t.Errorf("expected an ADDED event for uid-nameless, got %s %s (uid %s)", event.EventType, event.Key, event.Object.GetUID())
+	}
+	if got := controller.Metrics().KeyFuncErrorCount("v1/configmaps"); got != 1 {
+		t.Errorf("expected 1 key func error, got %v", got)
+	}
+}
+
+func TestAddResourcesAndWaitBlocksUntilSynced(t *testing.T) {
+	secret := newObject("v1", "Secret", "test-ns", "db-credentials", "uid-secret", nil)
+	client, _ := newFakeClient(secret)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := controller.AddResourcesAndWait(ctx, []faro.ResourceConfig{
+		{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}},
+	})
+	if err != nil {
+		t.Fatalf("AddResourcesAndWait failed: %v", err)
+	}
+
+	// No sleep: the new informer's cache must already be populated
+	obj, err := controller.GetObject("v1/secrets", "test-ns", "db-credentials")
+	if err != nil {
+		t.Fatalf("expected secret to be queryable right after AddResourcesAndWait: %v", err)
+	}
+	if string(obj.GetUID()) != "uid-secret" {
+		t.Errorf("expected UID uid-secret, got %s", obj.GetUID())
+	}
+}
+
+// failFirstList fails the first list of resource with the label selector ("" = any), so the
+// informer only syncs after the reflector's retry backoff. Sleeping in a reactor instead would
+// hold up every other list of the fake client too.
+func failFirstList(dynamicClient *dynamicfake.FakeDynamicClient, resource, labelSelector string) {
+	var failed atomic.Bool
+	dynamicClient.PrependReactor("list", resource, func(action clienttesting.Action) (bool, runtime.Object, error) {
+		list, ok := action.(clienttesting.ListAction)
+		if ok && (labelSelector == "" || list.GetListRestrictions().Labels.String() == labelSelector) && failed.CompareAndSwap(false, true) {
+			return true, nil, apierrors.NewInternalError(errors.New("list failed"))
+		}
+		return false, nil, nil
+	})
+}
+
+func TestAddResourcesAndWaitWaitsForLabelSelectorInformers(t *testing.T) {
+	secret := newObject("v1", "Secret", "test-ns", "db-credentials", "uid-secret", map[string]string{"tier": "db"})
+	client, dynamicClient := newFakeClient(secret)
+	failFirstList(dynamicClient, "secrets", "tier=db")
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := controller.AddResourcesAndWait(ctx, []faro.ResourceConfig{
+		{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}, LabelSelectors: []string{"app=web", "tier=db"}},
+	})
+	if err != nil {
+		t.Fatalf("AddResourcesAndWait failed: %v", err)
+	}
+
+	// The informer of the second selector syncs later, but must have synced too
+	secretInformers := 0
+	for _, status := range controller.DescribeInformers() {
+		if status.GVR != "v1/secrets" {
+			continue
+		}
+		secretInformers++
+		if !status.Synced {
+			t.Errorf("expected the informer with label selector %q to be synced", status.LabelSelector)
+		}
+	}
+	if secretInformers != 2 {
+		t.Errorf("expected 2 informers for the OR-ed label selectors, got %d", secretInformers)
+	}
+}
+
+func TestAddResourcesAndWaitWaitsForNamespaceLabelSelectorInformers(t *testing.T) {
+	namespace := newObject("v1", "Namespace", "", "team-a", "uid-ns-a", map[string]string{"faro": "watch"})
+	secret := newObject("v1", "Secret", "team-a", "db-credentials", "uid-secret", nil)
+	client, dynamicClient := newFakeClient(namespace, secret)
+	failFirstList(dynamicClient, "secrets", "")
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := controller.AddResourcesAndWait(ctx, []faro.ResourceConfig{
+		{GVR: "v1/secrets", NamespaceLabelSelector: "faro=watch"},
+	})
+	if err != nil {
+		t.Fatalf("AddResourcesAndWait failed: %v", err)
+	}
+
+	// The namespace watcher and the informer it starts in team-a have both synced
+	obj, err := controller.GetObject("v1/secrets", "team-a", "db-credentials")
+	if err != nil {
+		t.Fatalf("expected secret in the selected namespace to be queryable right after AddResourcesAndWait: %v", err)
+	}
+	if string(obj.GetUID()) != "uid-secret" {
+		t.Errorf("expected UID uid-secret, got %s", obj.GetUID())
+	}
+}
+
+func TestReloadCountsConfigReloads(t *testing.T) {
+	secret := newObject("v1", "Secret", "test-ns", "db-credentials", "uid-secret", nil)
+	client, _ := newFakeClient(secret)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	baseURL := enableTestMetrics(t, config)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Adding a resource config is applied and counted as a success
+	updated := *config
+	updated.Resources = append([]faro.ResourceConfig{}, config.Resources...)
+	updated.Resources = append(updated.Resources, faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}})
+	if err := controller.Reload(ctx, &updated); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if _, err := controller.GetObject("v1/secrets", "test-ns", "db-credentials"); err != nil {
+		t.Errorf("expected the reloaded resource to be watched: %v", err)
+	}
+	if got := controller.Metrics().ConfigReloadCount("success"); got != 1 {
+		t.Errorf("expected 1 successful reload, got %v", got)
+	}
+
+	// Dropping a running resource config needs a restart and is counted as an error
+	removed := updated
+	removed.Resources = updated.Resources[1:]
+	if err := controller.Reload(ctx, &removed); err == nil {
+		t.Error("expected Reload to reject removing a running resource config")
+	}
+	if got := controller.Metrics().ConfigReloadCount("error"); got != 1 {
+		t.Errorf("expected 1 failed reload, got %v", got)
+	}
+
+	// So does any change outside resources, which would otherwise be silently ignored
+	retuned := updated
+	retuned.DiscoveryWorkers = config.DiscoveryWorkers + 1
+	if err := controller.Reload(ctx, &retuned); err == nil || !strings.Contains(err.Error(), "discovery_workers") {
+		t.Errorf("expected Reload to reject a changed discovery_workers setting, got %v", err)
+	}
+	if got := controller.Metrics().ConfigReloadCount("error"); got != 2 {
+		t.Errorf("expected 2 failed reloads, got %v", got)
+	}
+
+	_, body := httpGet(t, baseURL+"/metrics")
+	if !strings.Contains(body, "faro_config_last_reload_timestamp") || strings.Contains(body, "faro_config_last_reload_timestamp 0") {
+		t.Errorf("expected faro_config_last_reload_timestamp to be set, got:\n%s", body)
+	}
+}
+
+func TestAddResourcesAndWaitRejectsUnknownGVR(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	err := controller.AddResourcesAndWait(context.Background(), []faro.ResourceConfig{
+		{GVR: "example.com/v1/widgets", NamespaceNames: []string{"test-ns"}},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a GVR missing from discovery")
+	}
+}
+
+func TestRepeatedAddResourcesStartsSingleInformer(t *testing.T) {
+	cm := newConfigMap("test-ns", "app-config", "uid-1", nil)
+	client, _ := newFakeClient(cm)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"test-ns"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	// Add the same target twice, restarting informers each time
+	for i := 0; i < 2; i++ {
+		controller.AddResources([]faro.ResourceConfig{{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}}})
+		if err := controller.StartInformers(); err != nil {
+			t.Fatalf("StartInformers failed: %v", err)
+		}
+	}
+
+	waitFor(t, "ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") >= 1 })
+	time.Sleep(500 * time.Millisecond) // Give a duplicate informer time to deliver
+
+	if added := countEvents(handler.Events(), "ADDED"); added != 1 {
+		t.Errorf("expected exactly 1 ADDED event, got %d", added)
+	}
+	if configInformers, _ := controller.GetActiveInformers(); configInformers != 2 {
+		t.Errorf("expected 2 active informers (secrets + configmaps), got %d", configInformers)
+	}
+}
+
+func TestDiscoveryTimeoutAbortsStart(t *testing.T) {
+	client, _ := newFakeClient()
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	client.Discovery.(*discoveryfake.FakeDiscovery).PrependReactor("get", "group", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		<-release // Simulate a wedged API server
+		return false, nil, nil
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope})
+	config.DiscoveryTimeoutSec = 1
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	t.Cleanup(controller.Stop)
+
+	started := time.Now()
+	err := controller.Start()
+	if err == nil {
+		t.Fatal("expected Start to fail when discovery blocks")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected discovery timeout error, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Start took %s, expected it to abort after the 1s timeout", elapsed)
+	}
+}
+
+// slowDiscovery delays every group/version lookup to simulate a large, slow cluster
+type slowDiscovery struct {
+	*discoveryfake.FakeDiscovery
+	delay time.Duration
+}
+
+func (d *slowDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	time.Sleep(d.delay)
+	return d.FakeDiscovery.ServerResourcesForGroupVersion(groupVersion)
+}
+
+// discoverWithWorkers starts a controller against groupCount slow API groups and returns its discovery results
+func discoverWithWorkers(t *testing.T, groupCount, workers int) (map[string]faro.ResourceInfo, time.Duration) {
+	t.Helper()
+	resources := append([]*metav1.APIResourceList(nil), fakeAPIResources...)
+	for i := 0; i < groupCount; i++ {
+		for _, version := range []string{"v1", "v1beta1"} {
+			resources = append(resources, &metav1.APIResourceList{
+				GroupVersion: fmt.Sprintf("group%d.example.com/%s", i, version),
+				APIResources: []metav1.APIResource{
+					{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: []string{"list", "watch"}},
+				},
+			})
+		}
+	}
+
+	client, _ := newFakeClient()
+	client.Discovery = &slowDiscovery{
+		FakeDiscovery: &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: resources}},
+		delay:         20 * time.Millisecond,
+	}
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope})
+	config.DiscoveryWorkers = workers
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+
+	started := time.Now()
+	startTestController(t, controller)
+	return controller.GetDiscoveredResources(), time.Since(started)
+}
+
+func TestParallelDiscoveryMatchesSequential(t *testing.T) {
+	const groupCount = 25
+
+	sequential, sequentialTime := discoverWithWorkers(t, groupCount, 1)
+	parallel, parallelTime := discoverWithWorkers(t, groupCount, 10)
+
+	if want := len(fakeAPIResources[0].APIResources) + groupCount*2; len(sequential) != want {
+		t.Fatalf("expected %d discovered resources, got %d", want, len(sequential))
+	}
+	if !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("parallel discovery results differ from sequential discovery")
+	}
+	if info := parallel["group7.example.com/v1beta1/widgets"]; info.Kind != "Widget" || info.Version != "v1beta1" {
+		t.Errorf("unexpected resource info for group7 widgets: %+v", info)
+	}
+	if parallelTime >= sequentialTime/2 {
+		t.Errorf("expected parallel discovery to be much faster: parallel %s, sequential %s", parallelTime, sequentialTime)
+	}
+}
+
+var (
+	crdGVR    = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	widgetGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+)
+
+// newCRDFakeClient returns a fake client that can list CRDs and example.com/v1 widgets; the
+// widgets.example.com CRD itself is not installed
+func newCRDFakeClient() (*faro.KubernetesClient, *dynamicfake.FakeDynamicClient) {
+	listKinds := map[schema.GroupVersionResource]string{
+		crdGVR:    "CustomResourceDefinitionList",
+		widgetGVR: "WidgetList",
+	}
+	for gvr, kind := range fakeListKinds {
+		listKinds[gvr] = kind
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	return &faro.KubernetesClient{
+		Dynamic:   dynamicClient,
+		Discovery: &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: fakeAPIResources}},
+	}, dynamicClient
+}
+
+// newWidgetCRD returns the widgets.example.com CRD serving example.com/v1/widgets
+func newWidgetCRD() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "widgets.example.com"},
+		"spec": map[string]interface{}{
+			"group": "example.com",
+			"scope": "Namespaced",
+			"names": map[string]interface{}{"plural": "widgets", "kind": "Widget"},
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1", "served": true, "storage": true},
+			},
+		},
+	}}
+}
+
+// unavailableGroupDiscovery fails discovery of one group version while down, like an aggregated
+// API whose APIService is unavailable
+type unavailableGroupDiscovery struct {
+	*discoveryfake.FakeDiscovery
+	groupVersion string
+	down         atomic.Bool
+}
+
+func (d *unavailableGroupDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	if groupVersion == d.groupVersion && d.down.Load() {
+		return nil, apierrors.NewServiceUnavailable("the server is currently unable to handle the request")
+	}
+	return d.FakeDiscovery.ServerResourcesForGroupVersion(groupVersion)
+}
+
+func TestUnavailableAPIServiceIsRetried(t *testing.T) {
+	podMetricsGVR := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	listKinds := map[schema.GroupVersionResource]string{podMetricsGVR: "PodMetricsList"}
+	for gvr, kind := range fakeListKinds {
+		listKinds[gvr] = kind
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	podMetrics := newObject("metrics.k8s.io/v1beta1", "PodMetrics", "default", "web-0", "uid-metrics", nil)
+	if _, err := dynamicClient.Resource(podMetricsGVR).Namespace("default").Create(context.Background(), podMetrics, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create PodMetrics: %v", err)
+	}
+	discovery := &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: append([]*metav1.APIResourceList{{
+		GroupVersion: "metrics.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "pods", Kind: "PodMetrics", Namespaced: true, Verbs: []string{"get", "list", "watch"}}},
+	}}, fakeAPIResources...)}}
+
+	// The aggregated API answers discovery with 503 until it comes back
+	unavailable := &unavailableGroupDiscovery{FakeDiscovery: discovery, groupVersion: "metrics.k8s.io/v1beta1"}
+	unavailable.down.Store(true)
+	client := &faro.KubernetesClient{Dynamic: dynamicClient, Discovery: unavailable}
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "metrics.k8s.io/v1beta1/pods", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.APIServiceRetrySec = 1
+	enableTestMetrics(t, config)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	if got := controller.Metrics().APIServiceAvailable("metrics.k8s.io"); got != 0 {
+		t.Errorf("expected metrics.k8s.io to be reported unavailable, got %v", got)
+	}
+	if informers := controller.DescribeInformers(); len(informers) != 0 {
+		t.Errorf("expected no informer for the degraded GVR, got %+v", informers)
+	}
+
+	unavailable.down.Store(false)
+	waitFor(t, "PodMetrics ADDED event after the APIService recovers", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+	if got := controller.Metrics().APIServiceAvailable("metrics.k8s.io"); got != 1 {
+		t.Errorf("expected metrics.k8s.io to be reported available again, got %v", got)
+	}
+}
+
+func TestSharedDiscoveryCacheRetriesUnavailableAPIService(t *testing.T) {
+	podMetricsGVR := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	listKinds := map[schema.GroupVersionResource]string{podMetricsGVR: "PodMetricsList"}
+	for gvr, kind := range fakeListKinds {
+		listKinds[gvr] = kind
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	podMetrics := newObject("metrics.k8s.io/v1beta1", "PodMetrics", "default", "web-0", "uid-metrics", nil)
+	if _, err := dynamicClient.Resource(podMetricsGVR).Namespace("default").Create(context.Background(), podMetrics, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create PodMetrics: %v", err)
+	}
+	discovery := &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: append([]*metav1.APIResourceList{{
+		GroupVersion: "metrics.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "pods", Kind: "PodMetrics", Namespaced: true, Verbs: []string{"get", "list", "watch"}}},
+	}}, fakeAPIResources...)}}
+	unavailable := &unavailableGroupDiscovery{FakeDiscovery: discovery, groupVersion: "metrics.k8s.io/v1beta1"}
+	unavailable.down.Store(true)
+	client := &faro.KubernetesClient{Dynamic: dynamicClient, Discovery: unavailable}
+
+	// The second controller takes its discovery results from the cache the first one filled
+	cache := faro.NewDiscoveryCache(0)
+	var handlers []*recordingHandler
+	for i := 0; i < 2; i++ {
+		config := newTestConfig(t, faro.ResourceConfig{GVR: "metrics.k8s.io/v1beta1/pods", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+		config.APIServiceRetrySec = 1
+		controller := faro.NewControllerWithDiscovery(client, newTestLogger(t, config), config, cache)
+		handler := &recordingHandler{}
+		controller.AddEventHandler(handler)
+		startTestController(t, controller)
+		handlers = append(handlers, handler)
+	}
+	if discoveries := cache.Discoveries(); discoveries != 1 {
+		t.Fatalf("expected one shared discovery, got %d", discoveries)
+	}
+
+	unavailable.down.Store(false)
+	for i, handler := range handlers {
+		waitFor(t, fmt.Sprintf("PodMetrics ADDED event of controller %d after the APIService recovers", i), func() bool {
+			return countEvents(handler.Events(), "ADDED") == 1
+		})
+	}
+}
+
+func TestWatchCRDsStartsInformersForNewCRD(t *testing.T) {
+	client, dynamicClient := newCRDFakeClient()
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "example.com/v1/widgets", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.WatchCRDs = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+
+	discovered := make(chan string, 1)
+	controller.SetCRDDiscoveredCallback(func(crdName, gvrString string) {
+		discovered <- crdName + " " + gvrString
+	})
+	startTestController(t, controller)
+
+	// Create a custom resource, then install its CRD after Start
+	widget := newObject("example.com/v1", "Widget", "default", "gadget", "uid-widget", nil)
+	if _, err := dynamicClient.Resource(widgetGVR).Namespace("default").Create(context.Background(), widget, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create widget: %v", err)
+	}
+	if _, err := dynamicClient.Resource(crdGVR).Create(context.Background(), newWidgetCRD(), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create CRD: %v", err)
+	}
+
+	select {
+	case got := <-discovered:
+		if got != "widgets.example.com example.com/v1/widgets" {
+			t.Errorf("unexpected CRD callback: %s", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for CRD discovered callback")
+	}
+
+	// Custom resources of the new CRD are now monitored
+	waitFor(t, "widget ADDED event", func() bool {
+		for _, event := range handler.Events() {
+			if event.GVR == "example.com/v1/widgets" && event.EventType == "ADDED" {
+				return true
+			}
+		}
+		return false
+	})
+
+	// Deleting the CRD stops its informer again
+	if err := dynamicClient.Resource(crdGVR).Delete(context.Background(), "widgets.example.com", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete CRD: %v", err)
+	}
+	waitFor(t, "widget informer to stop", func() bool {
+		for _, status := range controller.DescribeInformers() {
+			if status.GVR == "example.com/v1/widgets" {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestCRDReAddWithinDebounceKeepsInformer(t *testing.T) {
+	client, dynamicClient := newCRDFakeClient()
+	var widgetLists atomic.Int32
+	dynamicClient.PrependReactor("list", "widgets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		widgetLists.Add(1)
+		return false, nil, nil
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "example.com/v1/widgets", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.WatchCRDs = true
+	config.CRDReAddDebounceSec = 1
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	crds := dynamicClient.Resource(crdGVR)
+	if _, err := crds.Create(context.Background(), newWidgetCRD(), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create CRD: %v", err)
+	}
+	waitFor(t, "widget informer to start", func() bool { return widgetLists.Load() == 1 })
+
+	// An upgrade deletes and re-creates the CRD well within the window
+	if err := crds.Delete(context.Background(), "widgets.example.com", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete CRD: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if _, err := crds.Create(context.Background(), newWidgetCRD(), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to re-create CRD: %v", err)
+	}
+
+	time.Sleep(2 * time.Second) // Past the window: a teardown would have run by now
+	running := false
+	for _, status := range controller.DescribeInformers() {
+		if status.GVR == "example.com/v1/widgets" {
+			running = true
+		}
+	}
+	if !running {
+		t.Error("expected the widget informer to keep running after the CRD was re-added")
+	}
+	if lists := widgetLists.Load(); lists != 1 {
+		t.Errorf("expected the widget informer to never restart, got %d lists", lists)
+	}
+}
+
+func TestCRDUpdateRestartsInformersOfNonStorageVersion(t *testing.T) {
+	client, dynamicClient := newCRDFakeClient()
+	var widgetLists atomic.Int32
+	dynamicClient.PrependReactor("list", "widgets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		widgetLists.Add(1)
+		return false, nil, nil
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "example.com/v1/widgets", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.WatchCRDs = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	crds := dynamicClient.Resource(crdGVR)
+	if _, err := crds.Create(context.Background(), newWidgetCRD(), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create CRD: %v", err)
+	}
+	waitFor(t, "widget informer to start", func() bool { return widgetLists.Load() == 1 })
+
+	// v2 becomes the storage version; the configured v1 stays served but its informer restarts
+	crd := newWidgetCRD()
+	if err := unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"name": "v1", "served": true, "storage": false},
+		map[string]interface{}{"name": "v2", "served": true, "storage": true},
+	}, "spec", "versions"); err != nil {
+		t.Fatalf("failed to set CRD versions: %v", err)
+	}
+	if _, err := crds.Update(context.Background(), crd, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update CRD: %v", err)
+	}
+	waitFor(t, "widget informer to restart", func() bool { return widgetLists.Load() == 2 })
+
+	running := 0
+	for _, status := range controller.DescribeInformers() {
+		if status.GVR == "example.com/v1/widgets" {
+			running++
+		}
+	}
+	if running != 1 {
+		t.Errorf("expected one running v1 widget informer after the restart, got %d", running)
+	}
+}
+
+func TestCircuitBreakerStopsCrashLoopingInformer(t *testing.T) {
+	client, dynamicClient := newFakeClient()
+	dynamicClient.PrependReactor("list", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("simulated API failure")
+	})
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}},
+		faro.ResourceConfig{GVR: "v1/secrets", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}},
+	)
+	config.MaxInformerRestarts = 1
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+
+	failed := make(chan string, 1)
+	controller.SetInformerFailedCallback(func(gvrString, namespace string, err error) {
+		failed <- gvrString + "@" + namespace
+	})
+	startTestController(t, controller)
+
+	select {
+	case key := <-failed:
+		if key != "v1/configmaps@default" {
+			t.Errorf("expected breaker to open for v1/configmaps@default, got %s", key)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the circuit breaker to open")
+	}
+
+	// The healthy informer keeps delivering events
+	secret := newObject("v1", "Secret", "default", "still-watched", "uid-secret", nil)
+	if _, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}).Namespace("default").Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	waitFor(t, "secret ADDED event", func() bool {
+		for _, event := range handler.Events() {
+			if event.GVR == "v1/secrets" && event.Object.GetName() == "still-watched" {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func TestJSONExportIncludesObjectBody(t *testing.T) {
+	cm := newConfigMap("test-ns", "app-config", "uid-1", nil)
+	cm.Object["data"] = map[string]interface{}{"mode": "production"}
+	cm.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}})
+	client, _ := newFakeClient(cm)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.JsonExport = true
+	config.JsonIncludeObject = true
+	config.JsonStripManagedFields = true
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	event := waitForJSONEvents(t, config, 1)[0]
+	if event.Object == nil {
+		t.Fatal("expected object body in JSON event")
+	}
+
+	// The exported body round-trips into a valid object
+	body, err := json.Marshal(event.Object)
+	if err != nil {
+		t.Fatalf("failed to marshal object body: %v", err)
+	}
+	roundTripped := &unstructured.Unstructured{}
+	if err := roundTripped.UnmarshalJSON(body); err != nil {
+		t.Fatalf("object body is not a valid object: %v", err)
+	}
+	if mode, _, _ := unstructured.NestedString(roundTripped.Object, "data", "mode"); mode != "production" {
+		t.Errorf("expected data.mode=production in object body, got %q", mode)
+	}
+	if roundTripped.GetName() != "app-config" || string(roundTripped.GetUID()) != "uid-1" {
+		t.Errorf("unexpected object metadata: %s/%s", roundTripped.GetName(), roundTripped.GetUID())
+	}
+	if len(roundTripped.GetManagedFields()) != 0 {
+		t.Errorf("expected managedFields to be stripped, got %v", roundTripped.GetManagedFields())
+	}
+}
+
+func TestJSONExportIncludesKindFromDiscovery(t *testing.T) {
+	client, _ := newFakeClient(newConfigMap("test-ns", "app-config", "uid-1", nil))
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.JsonExport = true
+	config.JsonIncludeKind = true
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	event := waitForJSONEvents(t, config, 1)[0]
+	if expected := controller.GetDiscoveredResources()["v1/configmaps"].Kind; event.Kind != expected {
+		t.Errorf("expected kind %q from discovery, got %q", expected, event.Kind)
+	}
+	if event.Kind != "ConfigMap" {
+		t.Errorf("expected kind ConfigMap, got %q", event.Kind)
+	}
+}
+
+func TestInvolvedObjectGVRUsesDiscoveredPlurals(t *testing.T) {
+	client, _ := newFakeClient()
+	client.Discovery = &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "endpoints", Kind: "Endpoints", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+		{
+			GroupVersion: "networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "networkpolicies/status", Kind: "NetworkPolicy", Namespaced: true, Verbs: []string{"get"}},
+				{Name: "networkpolicies", Kind: "NetworkPolicy", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+	}}}
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	coreEvent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Event",
+		"involvedObject": map[string]interface{}{
+			"apiVersion": "v1", "kind": "Endpoints", "namespace": "test-ns", "name": "web",
+		},
+	}}
+	eventsV1Event := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "events.k8s.io/v1",
+		"kind":       "Event",
+		"regarding": map[string]interface{}{
+			"apiVersion": "networking.k8s.io/v1", "kind": "NetworkPolicy", "namespace": "test-ns", "name": "deny-all",
+		},
+	}}
+
+	for _, tc := range []struct {
+		event                *unstructured.Unstructured
+		gvr, namespace, name string
+	}{
+		{coreEvent, "v1/endpoints", "test-ns", "web"},
+		{eventsV1Event, "networking.k8s.io/v1/networkpolicies", "test-ns", "deny-all"},
+	} {
+		gvr, namespace, name, ok := controller.InvolvedObjectGVR(tc.event)
+		if !ok || gvr != tc.gvr || namespace != tc.namespace || name != tc.name {
+			t.Errorf("expected %s %s/%s, got %q %q/%q (ok=%v)", tc.gvr, tc.namespace, tc.name, gvr, namespace, name, ok)
+		}
+	}
+
+	unknown := &unstructured.Unstructured{Object: map[string]interface{}{
+		"involvedObject": map[string]interface{}{"apiVersion": "example.com/v1", "kind": "Widget", "name": "w"},
+	}}
+	if gvr, _, _, ok := controller.InvolvedObjectGVR(unknown); ok {
+		t.Errorf("expected an undiscovered kind not to resolve, got %q", gvr)
+	}
+}
+
+func TestJSONTimeFormat(t *testing.T) {
+	// creationTimestamp has second precision
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for format, want := range map[string]string{
+		"":                           "2020-01-02T03:04:05Z",
+		faro.JsonTimeFormatUnix:      "1577934245",
+		faro.JsonTimeFormatUnixMilli: "1577934245000",
+		"02/01/2006 15:04":           "02/01/2020 03:04",
+	} {
+		t.Run(format, func(t *testing.T) {
+			cm := newConfigMap("test-ns", "timeline", "uid-1", nil)
+			cm.SetCreationTimestamp(metav1.NewTime(created))
+			client, _ := newFakeClient(cm)
+
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+			config.JsonExport = true
+			config.JsonTimeFormat = format
+			if err := config.Validate(); err != nil {
+				t.Fatalf("expected json_time_format %q to be valid: %v", format, err)
+			}
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			startTestController(t, controller)
+
+			if stamp := waitForJSONEvents(t, config, 1)[0].Timestamp; stamp != want {
+				t.Errorf("expected timestamp %q, got %q", want, stamp)
+			}
+		})
+	}
+
+	config := newTestConfig(t)
+	config.JsonTimeFormat = "no-time-elements"
+	if err := config.Validate(); err == nil {
+		t.Error("expected a layout without time elements to be rejected")
+	}
+}
+
+// fixedClock is a faro.Clock stopped at one instant
+type fixedClock struct{ now time.Time }
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func TestRecentEventsReplaysLastEventsInOrder(t *testing.T) {
+	client, dynamicClient := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"test-ns"}})
+	config.EventRingSize = 3
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	names := func(events []faro.MatchedEvent) []string {
+		var result []string
+		for _, event := range events {
+			result = append(result, event.Object.GetName())
+		}
+		return result
+	}
+
+	// Create one at a time so the ring order is the creation order
+	var since time.Time
+	for i := 1; i <= 5; i++ {
+		name := fmt.Sprintf("cm-%d", i)
+		cm := newConfigMap("test-ns", name, fmt.Sprintf("uid-%d", i), nil)
+		if _, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns").Create(context.Background(), cm, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Failed to create ConfigMap: %v", err)
+		}
+		waitFor(t, "ring to record "+name, func() bool {
+			recent := names(controller.RecentEvents(1))
+			return len(recent) == 1 && recent[0] == name
+		})
+		if i == 3 {
+			since = time.Now()
+		}
+	}
+
+	if got := names(controller.RecentEvents(0)); !reflect.DeepEqual(got, []string{"cm-3", "cm-4", "cm-5"}) {
+		t.Errorf("expected the ring to hold the last 3 events oldest first, got %v", got)
+	}
+	if got := names(controller.RecentEvents(2)); !reflect.DeepEqual(got, []string{"cm-4", "cm-5"}) {
+		t.Errorf("expected the 2 most recent events, got %v", got)
+	}
+	if got := names(controller.EventsSince(since)); !reflect.DeepEqual(got, []string{"cm-4", "cm-5"}) {
+		t.Errorf("expected the events dispatched after cm-3, got %v", got)
+	}
+
+	// Replayed events are copies
+	controller.RecentEvents(1)[0].Object.SetName("modified")
+	if got := names(controller.RecentEvents(1)); got[0] != "cm-5" {
+		t.Errorf("expected modifying a replayed event to leave the ring intact, got %v", got)
+	}
+}
+
+func TestSetClockMakesProcessedAtDeterministic(t *testing.T) {
+	client, dynamicClient := newFakeClient(newConfigMap("test-ns", "timeline", "uid-1", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.JsonExport = true
+	config.JsonTimestampSource = faro.JsonTimestampBoth
+
+	fixed := time.Date(2030, 6, 7, 8, 9, 10, 0, time.UTC)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	controller.SetClock(fixedClock{now: fixed})
+	startTestController(t, controller)
+	waitForJSONEvents(t, config, 1)
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if err := configMaps.Delete(context.Background(), "timeline", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+
+	want := fixed.Format(time.RFC3339Nano)
+	for _, event := range waitForJSONEvents(t, config, 2) {
+		if event.ProcessedAt != want {
+			t.Errorf("%s: expected processedAt %s from the clock, got %s", event.EventType, want, event.ProcessedAt)
+		}
+		if event.EventType == "DELETED" && event.Timestamp != want {
+			t.Errorf("expected the DELETED timestamp %s from the clock, got %s", want, event.Timestamp)
+		}
+	}
+}
+
+func TestJSONTimestampSource(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	createdStamp := created.Format(time.RFC3339Nano)
+
+	for _, source := range []string{faro.JsonTimestampCreation, faro.JsonTimestampProcessing, faro.JsonTimestampBoth} {
+		t.Run(source, func(t *testing.T) {
+			cm := newConfigMap("test-ns", "timeline", "uid-1", nil)
+			cm.SetCreationTimestamp(metav1.NewTime(created))
+			client, dynamicClient := newFakeClient(cm)
+
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+			config.JsonExport = true
+			config.JsonTimestampSource = source
+
+			started := time.Now().UTC()
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			startTestController(t, controller)
+			waitForJSONEvents(t, config, 1)
+
+			configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+			cm.SetLabels(map[string]string{"revision": "2"})
+			if _, err := configMaps.Update(context.Background(), cm, metav1.UpdateOptions{}); err != nil {
+				t.Fatalf("Failed to update ConfigMap: %v", err)
+			}
+			waitForJSONEvents(t, config, 2)
+			if err := configMaps.Delete(context.Background(), "timeline", metav1.DeleteOptions{}); err != nil {
+				t.Fatalf("Failed to delete ConfigMap: %v", err)
+			}
+
+			for _, event := range waitForJSONEvents(t, config, 3) {
+				wantCreation := source != faro.JsonTimestampProcessing && event.EventType != "DELETED"
+				if wantCreation {
+					if event.Timestamp != createdStamp {
+						t.Errorf("%s: expected creation timestamp %s, got %s", event.EventType, createdStamp, event.Timestamp)
+					}
+				} else if stamp, err := time.Parse(time.RFC3339Nano, event.Timestamp); err != nil || stamp.Before(started) {
+					t.Errorf("%s: expected processing timestamp after %s, got %s", event.EventType, started, event.Timestamp)
+				}
+
+				if source == faro.JsonTimestampBoth {
+					if stamp, err := time.Parse(time.RFC3339Nano, event.ProcessedAt); err != nil || stamp.Before(started) {
+						t.Errorf("%s: expected processedAt after %s, got %q", event.EventType, started, event.ProcessedAt)
+					}
+				} else if event.ProcessedAt != "" {
+					t.Errorf("%s: expected no processedAt, got %q", event.EventType, event.ProcessedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestMissedDeleteDeliveredAsTombstoneIsHandled(t *testing.T) {
+	cm := newConfigMap("default", "missed", "uid-missed", nil)
+	client, dynamicClient := newFakeClient(cm)
+
+	// Serve watches from a controllable watcher that is not fed by the object tracker
+	var watchersMu sync.Mutex
+	var watchers []*watch.FakeWatcher
+	dynamicClient.PrependWatchReactor("configmaps", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFake()
+		watchersMu.Lock()
+		watchers = append(watchers, watcher)
+		watchersMu.Unlock()
+		return true, watcher, nil
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.JsonExport = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "initial ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+
+	// Delete while the watch is "disconnected", then drop the watch to force a relist
+	configMapsGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	if err := dynamicClient.Tracker().Delete(configMapsGVR, "default", "missed"); err != nil {
+		t.Fatalf("failed to delete configmap: %v", err)
+	}
+	watchersMu.Lock()
+	watchers[0].Stop()
+	watchersMu.Unlock()
+
+	waitFor(t, "DELETED event from tombstone", func() bool { return countEvents(handler.Events(), "DELETED") == 1 })
+
+	// The exported delete keeps the UID cached before the disconnect
+	deleted := waitForJSONEvents(t, config, 2)[1]
+	if deleted.EventType != "DELETED" || deleted.Name != "missed" || deleted.UID != "uid-missed" {
+		t.Errorf("unexpected exported delete: %+v", deleted)
+	}
+}
+
+// workloadAPIResources extends the fake API surface with pods, replicasets and deployments
+var workloadAPIResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+		},
+	},
+	{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			// Subresources share their parent's Kind and must not be resolved as owners
+			{Name: "replicasets/status", Kind: "ReplicaSet", Namespaced: true, Verbs: []string{"get", "patch", "update"}},
+			{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+			{Name: "deployments/status", Kind: "Deployment", Namespaced: true, Verbs: []string{"get", "patch", "update"}},
+			{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+		},
+	},
+}
+
+// newWorkloadFakeClient creates a fake client serving pods, replicasets and deployments
+func newWorkloadFakeClient(objects ...runtime.Object) (*faro.KubernetesClient, *dynamicfake.FakeDynamicClient) {
+	listKinds := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "pods"}:                       "PodList",
+		{Group: "apps", Version: "v1", Resource: "replicasets"}: "ReplicaSetList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}: "DeploymentList",
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objects...)
+	discoveryClient := &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: workloadAPIResources}}
+
+	return &faro.KubernetesClient{
+		Dynamic:   dynamicClient,
+		Discovery: discoveryClient,
+	}, dynamicClient
+}
+
+// setOwner makes owner the controller owner of obj
+func setOwner(obj, owner *unstructured.Unstructured) {
+	isController := true
+	obj.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: owner.GetAPIVersion(),
+		Kind:       owner.GetKind(),
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+		Controller: &isController,
+	}})
+}
+
+func TestJSONExportRecordsOwnerChain(t *testing.T) {
+	deployment := newObject("apps/v1", "Deployment", "default", "web", "uid-deploy", nil)
+	replicaSet := newObject("apps/v1", "ReplicaSet", "default", "web-abc", "uid-rs", nil)
+	setOwner(replicaSet, deployment)
+	client, dynamicClient := newWorkloadFakeClient(deployment, replicaSet)
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "apps/v1/deployments", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}},
+		faro.ResourceConfig{GVR: "apps/v1/replicasets", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}},
+		faro.ResourceConfig{GVR: "v1/pods", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}},
+	)
+	config.JsonExport = true
+	config.JsonIncludeOwners = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	// Owners are cached once their ADDED events are exported
+	waitForJSONEvents(t, config, 2)
+
+	pod := newObject("v1", "Pod", "default", "web-abc-xyz", "uid-pod", nil)
+	setOwner(pod, replicaSet)
+	if _, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "pods"}).Namespace("default").Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+
+	var podEvent faro.JSONEvent
+	for _, event := range waitForJSONEvents(t, config, 3) {
+		if event.GVR == "v1/pods" {
+			podEvent = event
+		}
+	}
+
+	want := []faro.EventOwner{
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-abc", UID: "uid-rs"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "uid-deploy"},
+	}
+	if !reflect.DeepEqual(podEvent.Owners, want) {
+		t.Errorf("unexpected owner chain:\n got: %+v\nwant: %+v", podEvent.Owners, want)
+	}
+}
+
+func TestJSONExportOwnerChainStopsAtUncachedOwner(t *testing.T) {
+	deployment := newObject("apps/v1", "Deployment", "default", "web", "uid-deploy", nil)
+	replicaSet := newObject("apps/v1", "ReplicaSet", "default", "web-abc", "uid-rs", nil)
+	setOwner(replicaSet, deployment)
+	client, _ := newWorkloadFakeClient(replicaSet)
+
+	// Deployments are not watched, so the chain ends with the RS's reference to it
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "apps/v1/replicasets", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.JsonExport = true
+	config.JsonIncludeOwners = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	event := waitForJSONEvents(t, config, 1)[0]
+	want := []faro.EventOwner{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "uid-deploy"}}
+	if !reflect.DeepEqual(event.Owners, want) {
+		t.Errorf("unexpected owner chain:\n got: %+v\nwant: %+v", event.Owners, want)
+	}
+}
+
+// blockingMiddleware blocks JSON export until released, simulating a stuck handler
+type blockingMiddleware struct {
+	entered chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (m *blockingMiddleware) ProcessBeforeJSON(eventType, gvr, namespace, name, uid string, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool) {
+	m.once.Do(func() { close(m.entered) })
+	<-m.release
+	return obj, true
+}
+
+func TestStopPersistsEventsQueuedBeforeStop(t *testing.T) {
+	// Twice as many objects as workers, so half the events are still queued at Stop
+	var objects []runtime.Object
+	for i := 0; i < 6; i++ {
+		objects = append(objects, newConfigMap("default", fmt.Sprintf("cm-%d", i), fmt.Sprintf("uid-%d", i), nil))
+	}
+	client, _ := newFakeClient(objects...)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.JsonExport = true
+	logger := newTestLogger(t, config)
+	controller := faro.NewController(client, logger, config)
+	gate := &blockingMiddleware{entered: make(chan struct{}), release: make(chan struct{})}
+	controller.AddJSONMiddleware(gate)
+	handler := &contextRecordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	// All events are queued but none is exported yet when shutdown begins
+	waitFor(t, "all ADDED events pending", func() bool { return controller.PendingWorkItems() == 6 })
+	stopped := make(chan struct{})
+	go func() {
+		controller.Stop()
+		close(stopped)
+	}()
+	waitFor(t, "shutdown to begin", func() bool { return !controller.Healthy() })
+	time.Sleep(100 * time.Millisecond) // Let Stop stop the informers before the queue drains
+	close(gate.release)
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop did not return")
+	}
+	logger.Shutdown()
+
+	if events := readJSONEvents(t, config); len(events) != 6 {
+		t.Errorf("expected the 6 events queued before Stop in the JSON export, got %d", len(events))
+	}
+	if calls, cancelled := handler.Calls(); calls != 6 || cancelled != 0 {
+		t.Errorf("expected the 6 events delivered with a live context, got %d calls, %d with a cancelled context", calls, cancelled)
+	}
+}
+
+// contextRecordingHandler counts OnMatchedCtx calls and those whose context was already done
+type contextRecordingHandler struct {
+	recordingHandler
+	mu        sync.Mutex
+	calls     int
+	cancelled int
+}
+
+func (h *contextRecordingHandler) OnMatchedCtx(ctx context.Context, event faro.MatchedEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls++
+	if ctx.Err() != nil {
+		h.cancelled++
+	}
+	return nil
+}
+
+func (h *contextRecordingHandler) Calls() (calls, cancelled int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls, h.cancelled
+}
+
+func TestStopPersistsEventsInDedupWindow(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("default", "first", "uid-1", nil),
+		newConfigMap("default", "second", "uid-2", nil),
+		newConfigMap("default", "third", "uid-3", nil),
+	)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.JsonExport = true
+	config.DedupWindowMs = 60000 // Far longer than the test - only Stop can close the windows
+	logger := newTestLogger(t, config)
+	controller := faro.NewController(client, logger, config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "informer sync", controller.Ready)
+	time.Sleep(200 * time.Millisecond)
+	if count := len(handler.Events()); count != 0 {
+		t.Fatalf("expected the ADDED events to wait in their dedup windows, got %d events", count)
+	}
+
+	controller.Stop()
+	logger.Shutdown()
+
+	if events := readJSONEvents(t, config); len(events) != 3 {
+		t.Errorf("expected the 3 events in open dedup windows in the JSON export, got %d", len(events))
+	}
+}
+
+// hangingContextHandler blocks in OnMatchedCtx until its context is cancelled
+type hangingContextHandler struct {
+	recordingHandler
+	cancelled chan time.Duration // How long each call waited for cancellation
+}
+
+func (h *hangingContextHandler) Name() string { return "hanging-sink" }
+
+func (h *hangingContextHandler) OnMatchedCtx(ctx context.Context, event faro.MatchedEvent) error {
+	started := time.Now()
+	<-ctx.Done()
+	h.cancelled <- time.Since(started)
+	return ctx.Err()
+}
+
+func TestHandlerTimeoutCancelsContextHandler(t *testing.T) {
+	for _, queued := range []bool{false, true} {
+		t.Run(fmt.Sprintf("queued=%v", queued), func(t *testing.T) {
+			client, _ := newFakeClient(newConfigMap("default", "slow", "uid-slow", nil))
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+			config.HandlerTimeoutSec = 1
+			if queued {
+				config.HandlerQueueSize = 10
+			}
+			enableTestMetrics(t, config)
+
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			handler := &hangingContextHandler{cancelled: make(chan time.Duration, 1)}
+			controller.AddEventHandler(handler)
+			startTestController(t, controller)
+
+			select {
+			case waited := <-handler.cancelled:
+				if waited < time.Second || waited > 3*time.Second {
+					t.Errorf("expected the handler context to be cancelled after the 1s timeout, waited %s", waited)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("handler context was not cancelled")
+			}
+			if events := handler.Events(); len(events) != 0 {
+				t.Errorf("expected OnMatchedCtx instead of OnMatched, got %d OnMatched calls", len(events))
+			}
+			waitFor(t, "timeout metric", func() bool {
+				return controller.Metrics().HandlerTimeoutCount("hanging-sink") == 1
+			})
+		})
+	}
+}
+
+// gatedContextHandler holds every OnMatchedCtx call until release is closed, then records it
+type gatedContextHandler struct {
+	contextRecordingHandler
+	release chan struct{}
+}
+
+func (h *gatedContextHandler) OnMatchedCtx(ctx context.Context, event faro.MatchedEvent) error {
+	<-h.release
+	return h.contextRecordingHandler.OnMatchedCtx(ctx, event)
+}
+
+func TestStopDeliversQueuedEventsToContextHandlerWithLiveContext(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("default", "first", "uid-1", nil),
+		newConfigMap("default", "second", "uid-2", nil),
+		newConfigMap("default", "third", "uid-3", nil),
+	)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.HandlerQueueSize = 10
+	config.HandlerTimeoutSec = 30
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &gatedContextHandler{release: make(chan struct{})}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	// The events wait in the handler's queue until Stop drains it
+	stopped := make(chan struct{})
+	go func() {
+		controller.Stop()
+		close(stopped)
+	}()
+	waitFor(t, "shutdown to begin", func() bool { return !controller.Healthy() })
+	time.Sleep(100 * time.Millisecond)
+	close(handler.release)
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop did not return")
+	}
+
+	if calls, cancelled := handler.Calls(); calls != 3 || cancelled != 0 {
+		t.Errorf("expected the 3 queued events delivered with a live context, got %d calls, %d with a cancelled context", calls, cancelled)
+	}
+}
+
+func TestStopReturnsAfterTimeoutWithStuckHandler(t *testing.T) {
+	client, _ := newFakeClient(newConfigMap("default", "stuck", "uid-stuck", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"default"}})
+	config.StopTimeoutSec = 1
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	middleware := &blockingMiddleware{entered: make(chan struct{}), release: make(chan struct{})}
+	controller.AddJSONMiddleware(middleware)
+	startTestController(t, controller)
+	t.Cleanup(func() { close(middleware.release) })
+
+	select {
+	case <-middleware.entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the worker to block")
+	}
+
+	stopped := make(chan struct{})
+	started := time.Now()
+	go func() {
+		controller.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		if elapsed := time.Since(started); elapsed < time.Second {
+			t.Errorf("Stop returned after %s, expected it to wait for the 1s timeout", elapsed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not return within the configured timeout")
+	}
+}
+
+func TestListOnlyResourceIsRejected(t *testing.T) {
+	client, _ := newFakeClient()
+	coreResources := append([]metav1.APIResource(nil), fakeAPIResources[0].APIResources...)
+	coreResources = append(coreResources,
+		metav1.APIResource{Name: "componentstatuses", Kind: "ComponentStatus", Namespaced: false, Verbs: []string{"get", "list"}})
+	client.Discovery = &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: coreResources},
+	}}}
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/componentstatuses", Scope: faro.ClusterScope})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	discovered := controller.GetDiscoveredResources()
+	if discovered["v1/componentstatuses"].Watchable {
+		t.Error("expected list-only resource to be tagged as not watchable")
+	}
+	if !discovered["v1/configmaps"].Watchable {
+		t.Error("expected configmaps to be tagged as watchable")
+	}
+	if configInformers, _ := controller.GetActiveInformers(); configInformers != 0 {
+		t.Errorf("expected no informer for a list-only resource, got %d", configInformers)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := controller.AddResourcesAndWait(ctx, []faro.ResourceConfig{{GVR: "v1/componentstatuses", Scope: faro.ClusterScope}})
+	if err == nil || !strings.Contains(err.Error(), "does not support both list and watch") {
+		t.Errorf("expected a helpful list/watch error, got %v", err)
+	}
+}
+
+// recordingBatchHandler collects the batches delivered to it
+type recordingBatchHandler struct {
+	mu      sync.Mutex
+	batches [][]faro.MatchedEvent
+}
+
+func (r *recordingBatchHandler) OnBatch(events []faro.MatchedEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, events)
+	return nil
+}
+
+// Batches returns a snapshot of the recorded batches
+func (r *recordingBatchHandler) Batches() [][]faro.MatchedEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]faro.MatchedEvent(nil), r.batches...)
+}
+
+func TestBatchEventHandlerReceivesBatchesOfConfiguredSize(t *testing.T) {
+	var objects []runtime.Object
+	for i := 0; i < 6; i++ {
+		objects = append(objects, newConfigMap("test-ns", fmt.Sprintf("cm-%d", i), fmt.Sprintf("uid-%d", i), nil))
+	}
+	client, dynamicClient := newFakeClient(objects...)
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.BatchSize = 3
+	config.BatchIntervalMs = 60000 // Far longer than the test - only size or DELETE can flush
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingBatchHandler{}
+	controller.AddBatchEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "two full batches", func() bool { return len(handler.Batches()) == 2 })
+	for i, batch := range handler.Batches() {
+		if len(batch) != 3 {
+			t.Errorf("batch %d: expected 3 events, got %d", i, len(batch))
+		}
+	}
+
+	// A deletion must not wait for the batch to fill up
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if err := configMaps.Delete(context.Background(), "cm-0", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+	waitFor(t, "DELETED batch flushed immediately", func() bool { return len(handler.Batches()) == 3 })
+	if last := handler.Batches()[2]; len(last) != 1 || last[0].EventType != "DELETED" {
+		t.Errorf("expected a single DELETED event in the last batch, got %+v", last)
+	}
+}
+
+func TestClusterScopedNameSelectorListsOnlyThatObject(t *testing.T) {
+	widgets := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "clusterwidgets"}
+	objects := []runtime.Object{
+		newObject("example.com/v1", "ClusterWidget", "", "wanted", "uid-wanted", nil),
+		newObject("example.com/v1", "ClusterWidget", "", "other", "uid-other", nil),
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{widgets: "ClusterWidgetList"}, objects...)
+
+	// The fake client ignores field selectors, so apply metadata.name the way the API server would
+	var fieldSelectors []string
+	var mu sync.Mutex
+	dynamicClient.PrependReactor("list", "clusterwidgets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		selector := action.(clienttesting.ListAction).GetListRestrictions().Fields
+		mu.Lock()
+		fieldSelectors = append(fieldSelectors, selector.String())
+		mu.Unlock()
+
+		listed, err := dynamicClient.Tracker().List(widgets, schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ClusterWidget"}, "")
+		if err != nil {
+			return true, nil, err
+		}
+		list := listed.(*unstructured.UnstructuredList)
+		filtered := list.Items[:0]
+		for _, item := range list.Items {
+			if selector.Matches(fields.Set{"metadata.name": item.GetName()}) {
+				filtered = append(filtered, item)
+			}
+		}
+		list.Items = filtered
+		return true, list, nil
+	})
+
+	client := &faro.KubernetesClient{
+		Dynamic: dynamicClient,
+		Discovery: &discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: append([]*metav1.APIResourceList{{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "clusterwidgets", Kind: "ClusterWidget", Namespaced: false, Verbs: []string{"get", "list", "watch"}},
+			},
+		}}, fakeAPIResources...)}},
+	}
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "example.com/v1/clusterwidgets", Scope: faro.ClusterScope, NameSelector: "wanted"})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	if count := handler.waitForStableCount(t); count != 1 {
+		t.Fatalf("expected only the named object to be listed, got %d events", count)
+	}
+	if name := handler.Events()[0].Object.GetName(); name != "wanted" {
+		t.Errorf("expected event for 'wanted', got %q", name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, selector := range fieldSelectors {
+		if selector != "metadata.name=wanted" {
+			t.Errorf("expected list field selector metadata.name=wanted, got %q", selector)
+		}
+	}
+}
+
+// listOptionsRecorder wraps a dynamic client and records the Limit and ResourceVersion of
+// every List call and AllowWatchBookmarks of every Watch call, which the fake client drops
+// before reactors see the action
+type listOptionsRecorder struct {
+	dynamic.Interface
+	mu               sync.Mutex
+	limits           []int64
+	resourceVersions []string
+	watchBookmarks   []bool
+	failNext         error // Returned by the next List call instead of listing
+}
+
+// record stores the call's options and returns the error to fail it with, if any
+func (r *listOptionsRecorder) record(opts metav1.ListOptions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limits = append(r.limits, opts.Limit)
+	r.resourceVersions = append(r.resourceVersions, opts.ResourceVersion)
+	err := r.failNext
+	r.failNext = nil
+	return err
+}
+
+func (r *listOptionsRecorder) Limits() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64(nil), r.limits...)
+}
+
+func (r *listOptionsRecorder) ResourceVersions() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.resourceVersions...)
+}
+
+// recordWatch stores whether a Watch call asked for bookmarks
+func (r *listOptionsRecorder) recordWatch(opts metav1.ListOptions) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.watchBookmarks = append(r.watchBookmarks, opts.AllowWatchBookmarks)
+}
+
+func (r *listOptionsRecorder) WatchBookmarks() []bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]bool(nil), r.watchBookmarks...)
+}
+
+func (r *listOptionsRecorder) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &recordingResource{NamespaceableResourceInterface: r.Interface.Resource(gvr), recorder: r}
+}
+
+type recordingResource struct {
+	dynamic.NamespaceableResourceInterface
+	recorder *listOptionsRecorder
+}
+
+func (r *recordingResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &recordingNamespacedResource{ResourceInterface: r.NamespaceableResourceInterface.Namespace(namespace), recorder: r.recorder}
+}
+
+func (r *recordingResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	if err := r.recorder.record(opts); err != nil {
+		return nil, err
+	}
+	return r.NamespaceableResourceInterface.List(ctx, opts)
+}
+
+func (r *recordingResource) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	r.recorder.recordWatch(opts)
+	return r.NamespaceableResourceInterface.Watch(ctx, opts)
+}
+
+type recordingNamespacedResource struct {
+	dynamic.ResourceInterface
+	recorder *listOptionsRecorder
+}
+
+func (r *recordingNamespacedResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	if err := r.recorder.record(opts); err != nil {
+		return nil, err
+	}
+	return r.ResourceInterface.List(ctx, opts)
+}
+
+func (r *recordingNamespacedResource) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	r.recorder.recordWatch(opts)
+	return r.ResourceInterface.Watch(ctx, opts)
+}
+
+func TestNodeNameListsOnlyPodsOnThatNode(t *testing.T) {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	local := newObject("v1", "Pod", "default", "local", "uid-local", nil)
+	unstructured.SetNestedField(local.Object, "node-a", "spec", "nodeName")
+	remote := newObject("v1", "Pod", "default", "remote", "uid-remote", nil)
+	unstructured.SetNestedField(remote.Object, "node-b", "spec", "nodeName")
+	client, dynamicClient := newWorkloadFakeClient(local, remote)
+
+	// The fake client ignores field selectors, so apply spec.nodeName the way the API server would
+	var fieldSelectors []string
+	var mu sync.Mutex
+	dynamicClient.PrependReactor("list", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		selector := action.(clienttesting.ListAction).GetListRestrictions().Fields
+		mu.Lock()
+		fieldSelectors = append(fieldSelectors, selector.String())
+		mu.Unlock()
+
+		listed, err := dynamicClient.Tracker().List(pods, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "default")
+		if err != nil {
+			return true, nil, err
+		}
+		list := listed.(*unstructured.UnstructuredList)
+		filtered := list.Items[:0]
+		for _, item := range list.Items {
+			nodeName, _, _ := unstructured.NestedString(item.Object, "spec", "nodeName")
+			if selector.Matches(fields.Set{"spec.nodeName": nodeName}) {
+				filtered = append(filtered, item)
+			}
+		}
+		list.Items = filtered
+		return true, list, nil
+	})
+
+	// The node comes from the downward API through the environment
+	t.Setenv("NODE_NAME", "node-a")
+	config := newTestConfig(t, faro.WatchResource("v1/pods").InNamespaces("default").OnNode("$NODE_NAME"))
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	if count := handler.waitForStableCount(t); count != 1 {
+		t.Fatalf("expected only the pod on node-a to be listed, got %d events", count)
+	}
+	if name := handler.Events()[0].Object.GetName(); name != "local" {
+		t.Errorf("expected event for 'local', got %q", name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, selector := range fieldSelectors {
+		if selector != "spec.nodeName=node-a" {
+			t.Errorf("expected list field selector spec.nodeName=node-a, got %q", selector)
+		}
+	}
+}
+
+func TestNodeNameFiltersPodsWhenConfigsPinDifferentNodes(t *testing.T) {
+	var objects []runtime.Object
+	for _, node := range []string{"node-a", "node-b", "node-c"} {
+		pod := newObject("v1", "Pod", "default", "pod-on-"+node, "uid-"+node, nil)
+		unstructured.SetNestedField(pod.Object, node, "spec", "nodeName")
+		objects = append(objects, pod)
+	}
+	client, _ := newWorkloadFakeClient(objects...)
+
+	// Sharing one informer, the configs can't use a single spec.nodeName field selector
+	config := newTestConfig(t,
+		faro.WatchResource("v1/pods").InNamespaces("default").OnNode("node-a"),
+		faro.WatchResource("v1/pods").InNamespaces("default").OnNode("node-b"),
+	)
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	if count := handler.waitForStableCount(t); count != 2 {
+		t.Fatalf("expected only the pods on node-a and node-b, got %d events", count)
+	}
+	for _, event := range handler.Events() {
+		if event.Object.GetName() == "pod-on-node-c" {
+			t.Error("expected the pod on node-c to be filtered")
+		}
+	}
+}
+
+func TestInformerListPageSizeSetsListLimit(t *testing.T) {
+	client, dynamicClient := newFakeClient(newConfigMap("test-ns", "settings", "uid-1", nil))
+	recorder := &listOptionsRecorder{Interface: dynamicClient}
+	client.Dynamic = recorder
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"test-ns"}})
+	config.InformerListPageSize = 50
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+
+	limits := recorder.Limits()
+	if len(limits) == 0 {
+		t.Fatal("expected the informer to list configmaps")
+	}
+	for _, limit := range limits {
+		if limit != 50 {
+			t.Errorf("expected list limit 50, got %d", limit)
+		}
+	}
+}
+
+func TestFastStartListsFromWatchCache(t *testing.T) {
+	for _, fastStart := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fast_start=%v", fastStart), func(t *testing.T) {
+			client, dynamicClient := newFakeClient(newConfigMap("test-ns", "settings", "uid-1", nil))
+			// The first list fails as expired, so client-go retries with a consistent read
+			recorder := &listOptionsRecorder{
+				Interface: dynamicClient,
+				failNext:  apierrors.NewResourceExpired("resource version too old"),
+			}
+			client.Dynamic = recorder
+
+			config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"test-ns"}})
+			config.FastStart = fastStart
+			controller := faro.NewController(client, newTestLogger(t, config), config)
+			startTestController(t, controller)
+			waitFor(t, "informer sync", controller.Ready)
+
+			versions := recorder.ResourceVersions()
+			if len(versions) < 2 {
+				t.Fatalf("expected the expired list to be retried, got lists %q", versions)
+			}
+			want := []string{"0", ""}
+			if fastStart {
+				want = []string{"0", "0"}
+			}
+			if !reflect.DeepEqual(versions[:2], want) {
+				t.Errorf("expected list resource versions %q, got %q", want, versions[:2])
+			}
+		})
+	}
+}
+
+func TestUseBookmarksRequestsWatchBookmarks(t *testing.T) {
+	client, dynamicClient := newFakeClient(newConfigMap("test-ns", "settings", "uid-1", nil))
+	watcher := watch.NewFakeWithChanSize(10, false)
+	dynamicClient.PrependWatchReactor("configmaps", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, watcher, nil
+	})
+	recorder := &listOptionsRecorder{Interface: dynamicClient}
+	client.Dynamic = recorder
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", Scope: faro.NamespaceScope, NamespaceNames: []string{"test-ns"}})
+	config.UseBookmarks = true
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "informer sync", controller.Ready)
+	waitFor(t, "watch", func() bool { return len(recorder.WatchBookmarks()) > 0 })
+
+	for _, bookmarks := range recorder.WatchBookmarks() {
+		if !bookmarks {
+			t.Error("expected every watch to request bookmarks")
+		}
+	}
+
+	// A bookmark carries only a resourceVersion and must not reach the handlers
+	bookmark := &unstructured.Unstructured{}
+	bookmark.SetAPIVersion("v1")
+	bookmark.SetKind("ConfigMap")
+	bookmark.SetResourceVersion("100")
+	watcher.Action(watch.Bookmark, bookmark)
+	watcher.Add(newConfigMap("test-ns", "after-bookmark", "uid-2", nil))
+
+	waitFor(t, "event after the bookmark", func() bool {
+		for _, event := range handler.Events() {
+			if event.Key == "test-ns/after-bookmark" {
+				return true
+			}
+		}
+		return false
+	})
+	var keys []string
+	for _, event := range handler.Events() {
+		keys = append(keys, event.EventType+" "+event.Key)
+	}
+	sort.Strings(keys)
+	if want := []string{"ADDED test-ns/after-bookmark", "ADDED test-ns/settings"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("expected events %q, got %q", want, keys)
+	}
+}
+
+func TestPriorityWaitForSyncStartsDependentsAfterSync(t *testing.T) {
+	client, dynamicClient := newFakeClient(
+		newObject("v1", "Namespace", "", "team-a", "uid-ns", nil),
+		newConfigMap("team-a", "settings", "uid-cm", nil),
+	)
+
+	// Slow down the namespaces list so an unordered start would list configmaps first
+	var mu sync.Mutex
+	var namespacesListed, configMapsListed time.Time
+	dynamicClient.PrependReactor("list", "namespaces", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		time.Sleep(300 * time.Millisecond)
+		mu.Lock()
+		namespacesListed = time.Now()
+		mu.Unlock()
+		return false, nil, nil
+	})
+	dynamicClient.PrependReactor("list", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		if configMapsListed.IsZero() {
+			configMapsListed = time.Now()
+		}
+		mu.Unlock()
+		return false, nil, nil
+	})
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"team-a"}, Priority: 10},
+		faro.ResourceConfig{GVR: "v1/namespaces", Scope: faro.ClusterScope},
+	)
+	config.PriorityWaitForSync = true
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	waitFor(t, "both informers to deliver events", func() bool { return countEvents(handler.Events(), "ADDED") == 2 })
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !configMapsListed.After(namespacesListed) {
+		t.Errorf("expected configmaps (priority 10) to be listed after namespaces (priority 0) synced: namespaces %v, configmaps %v",
+			namespacesListed, configMapsListed)
+	}
+}
+
+func TestPriorityWaitForSyncGivesUpOnInformerThatNeverSyncs(t *testing.T) {
+	client, dynamicClient := newFakeClient(newConfigMap("team-a", "settings", "uid-cm", nil))
+	dynamicClient.PrependReactor("list", "namespaces", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", errors.New("RBAC denied"))
+	})
+
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"team-a"}, Priority: 10},
+		faro.ResourceConfig{GVR: "v1/namespaces", Scope: faro.ClusterScope},
+	)
+	config.PriorityWaitForSync = true
+	config.PrioritySyncTimeoutSec = 1
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	// The forbidden namespaces informer must not keep priority 10 from starting
+	waitFor(t, "configmap ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+}
+
+func TestPriorityWaitForSyncDoesNotDelayInformersStartedAtRuntime(t *testing.T) {
+	client, dynamicClient := newFakeClient(newConfigMap("team-a", "settings", "uid-cm", nil))
+	dynamicClient.PrependReactor("list", "namespaces", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", errors.New("RBAC denied"))
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/secrets", NamespaceNames: []string{"team-a"}})
+	config.PriorityWaitForSync = true
+	config.PrioritySyncTimeoutSec = 30
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	// Informers started after Start (new CRDs, recovered APIs, added resources) all start at once
+	controller.AddResources([]faro.ResourceConfig{
+		{GVR: "v1/configmaps", NamespaceNames: []string{"team-a"}, Priority: 10},
+		{GVR: "v1/namespaces", Scope: faro.ClusterScope},
+	})
+	started := time.Now()
+	if err := controller.StartInformers(); err != nil {
+		t.Fatalf("StartInformers failed: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("expected StartInformers not to wait for the forbidden lower priority, took %s", elapsed)
+	}
+	waitFor(t, "configmap ADDED event", func() bool { return countEvents(handler.Events(), "ADDED") == 1 })
+}
+
+func TestLabelSelectorsAreORedAndDeduplicated(t *testing.T) {
+	client, _ := newFakeClient(
+		newConfigMap("test-ns", "team-a", "uid-a", map[string]string{"team": "a"}),
+		newConfigMap("test-ns", "web", "uid-web", map[string]string{"tier": "web"}),
+		newConfigMap("test-ns", "team-a-web", "uid-both", map[string]string{"team": "a", "tier": "web"}),
+		newConfigMap("test-ns", "team-c", "uid-c", map[string]string{"team": "c"}),
+	)
+
+	config := newTestConfig(t, faro.ResourceConfig{
+		GVR:            "v1/configmaps",
+		NamespaceNames: []string{"test-ns"},
+		LabelSelectors: []string{"team=a", "tier=web"},
+	})
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	handler.waitForStableCount(t)
+	seen := make(map[string]int)
+	for _, event := range handler.Events() {
+		seen[event.Object.GetName()]++
+	}
+	expected := map[string]int{"team-a": 1, "web": 1, "team-a-web": 1}
+	if !reflect.DeepEqual(seen, expected) {
+		t.Errorf("expected each matching object exactly once, got %v", seen)
+	}
+
+	if configInformers, _ := controller.GetActiveInformers(); configInformers != 2 {
+		t.Errorf("expected one informer per label selector, got %d", configInformers)
+	}
+	if _, err := controller.GetObject("v1/configmaps", "test-ns", "web"); err != nil {
+		t.Errorf("expected GetObject to find an object cached by the second selector: %v", err)
+	}
+}
+
+// signallingHandler forwards every event to a channel, optionally declaring itself read-only
+type signallingHandler struct {
+	events   chan faro.MatchedEvent
+	readOnly bool
+}
+
+func (h *signallingHandler) OnMatched(event faro.MatchedEvent) error {
+	h.events <- event
+	return nil
+}
+
+func (h *signallingHandler) ReadOnlyEvents() bool { return h.readOnly }
+
+func BenchmarkDispatchLargeObject(b *testing.B) {
+	for _, readOnly := range []bool{false, true} {
+		name := "deep-copy"
+		if readOnly {
+			name = "read-only"
+		}
+		b.Run(name, func(b *testing.B) {
+			// A ConfigMap with enough keys for the per-handler copy to dominate
+			data := make(map[string]interface{}, 2000)
+			for i := 0; i < 2000; i++ {
+				data[fmt.Sprintf("key-%d", i)] = strings.Repeat("x", 64)
+			}
+			cm := newConfigMap("test-ns", "large", "uid-1", nil)
+			cm.Object["data"] = data
+			client, dynamicClient := newFakeClient(cm)
+
+			config := newTestConfig(b, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+			controller := faro.NewController(client, newTestLogger(b, config), config)
+			handlers := make([]*signallingHandler, 4)
+			for i := range handlers {
+				handlers[i] = &signallingHandler{events: make(chan faro.MatchedEvent, 1), readOnly: readOnly}
+				controller.AddEventHandler(handlers[i])
+			}
+			startTestController(b, controller)
+			for _, handler := range handlers {
+				<-handler.events // Initial ADDED
+			}
+
+			configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cm.SetLabels(map[string]string{"revision": fmt.Sprintf("%d", i)})
+				if _, err := configMaps.Update(context.Background(), cm, metav1.UpdateOptions{}); err != nil {
+					b.Fatalf("Failed to update ConfigMap: %v", err)
+				}
+				for _, handler := range handlers {
+					<-handler.events
+				}
+			}
+		})
+	}
+}
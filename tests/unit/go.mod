@@ -4,7 +4,11 @@ go 1.24.0
 
 toolchain go1.24.2
 
-require github.com/T0MASD/faro v0.0.0
+require (
+	github.com/T0MASD/faro v0.0.0
+	k8s.io/apimachinery v0.33.3
+	k8s.io/client-go v0.33.3
+)
 
 require (
 	github.com/beorn7/perks v1.0.1 // indirect
@@ -47,8 +51,6 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	k8s.io/api v0.33.3 // indirect
 	k8s.io/apiextensions-apiserver v0.33.3 // indirect
-	k8s.io/apimachinery v0.33.3 // indirect
-	k8s.io/client-go v0.33.3 // indirect
 	k8s.io/klog/v2 v2.130.1 // indirect
 	k8s.io/kube-openapi v0.0.0-20250318190949-c8a335a9a2ff // indirect
 	k8s.io/utils v0.0.0-20241104100929-3ea5e8cea738 // indirect
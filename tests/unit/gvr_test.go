@@ -0,0 +1,46 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+func TestParseGVR(t *testing.T) {
+	for _, tc := range []struct {
+		input   string
+		want    schema.GroupVersionResource
+		wantErr string
+	}{
+		{input: "v1/configmaps", want: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}},
+		{input: "batch/v1/jobs", want: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}},
+		{input: "example.com/v1alpha1/widgets", want: schema.GroupVersionResource{Group: "example.com", Version: "v1alpha1", Resource: "widgets"}},
+		{input: "", wantErr: "must be version/resource"},
+		{input: "configmaps", wantErr: "must be version/resource"},
+		{input: "apps/v1/deployments/scale", wantErr: "must be version/resource"},
+		{input: "/v1/configmaps", wantErr: "group must not be empty"},
+		{input: "apps//deployments", wantErr: "version must not be empty"},
+		{input: "v1/", wantErr: "resource must not be empty"},
+		{input: "v1/ConfigMaps", wantErr: "lowercase plural name (e.g. configmaps)"},
+		{input: "v1/config maps", wantErr: "whitespace"},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := faro.ParseGVR(tc.input)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package unit
 
 import (
 	"bufio"
+	"compress/gzip"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -788,4 +790,262 @@ func TestJSONFilePathOutput(t *testing.T) {
 	if !jsonFileFound {
 		t.Error("❌ JSON export file not found with expected naming pattern")
 	}
-}
\ No newline at end of file
+}
+func TestJSONComponentAllowList(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "faro-json-components-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	config := &faro.Config{
+		OutputDir:      tmpDir,
+		LogLevel:       "info",
+		JsonExport:     true,
+		JsonComponents: []string{"workload-event"},
+	}
+
+	logger, err := faro.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Shutdown()
+
+	logger.Info("workload-event", `{"eventType":"WORKLOAD_DETECTED","name":"custom"}`)
+	logger.Info("controller", `{"eventType":"ADDED","name":"builtin"}`)
+	logger.Info("other-component", `{"eventType":"ADDED","name":"dropped"}`)
+
+	files, err := filepath.Glob(filepath.Join(tmpDir, "logs", "events-*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No JSON export file found: %v", err)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Failed to read JSON file: %v", err)
+	}
+
+	if !strings.Contains(string(content), `"name":"custom"`) {
+		t.Error("expected JSON from whitelisted custom component in export")
+	}
+	if !strings.Contains(string(content), `"name":"builtin"`) {
+		t.Error("expected JSON from controller to still reach the export")
+	}
+	if strings.Contains(string(content), `"name":"dropped"`) {
+		t.Error("expected JSON from non-whitelisted component to be filtered")
+	}
+}
+
+func TestRepeatedErrorsAreCollapsed(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "faro-error-dedup-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	config := &faro.Config{
+		OutputDir:         tmpDir,
+		LogLevel:          "info",
+		LogDedupWindowSec: 1,
+	}
+
+	logger, err := faro.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Shutdown()
+
+	for i := 0; i < 5; i++ {
+		logger.Error("controller", "Error processing v1/configmaps test-ns/broken: boom")
+	}
+	logger.Error("controller", "Error processing v1/configmaps test-ns/other: boom")
+
+	// Let the window close so the suppressed count is reported
+	time.Sleep(1500 * time.Millisecond)
+
+	files, err := filepath.Glob(filepath.Join(tmpDir, "logs", "faro-*.log"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No log file found: %v", err)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	log := string(content)
+
+	// klog may write each error to several severity streams, so compare against the single error
+	single := strings.Count(log, "test-ns/other: boom\n")
+	if single == 0 {
+		t.Fatalf("expected a different error to be logged independently:\n%s", log)
+	}
+	if repeated := strings.Count(log, "test-ns/broken: boom\n"); repeated != single {
+		t.Errorf("expected the repeated error to be logged once, got %d lines (vs %d for a single error):\n%s", repeated, single, log)
+	}
+	if !strings.Contains(log, "test-ns/broken: boom (4 occurrences suppressed in the last 1s)") {
+		t.Errorf("expected a suppressed-count summary for the repeated error:\n%s", log)
+	}
+}
+
+func TestSeekEventsStartsWithinOneIndexInterval(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "faro-json-index-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	const interval = 100 * time.Millisecond
+	config := &faro.Config{
+		OutputDir:           tmpDir,
+		LogLevel:            "info",
+		JsonExport:          true,
+		JsonIndexIntervalMs: int(interval / time.Millisecond),
+	}
+
+	logger, err := faro.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	// Write an event every 20ms, remembering when each one was written
+	var written []time.Time
+	var target time.Time
+	for seq := 0; seq < 40; seq++ {
+		if seq == 20 {
+			target = time.Now()
+		}
+		written = append(written, time.Now())
+		logger.Info("controller", fmt.Sprintf(`{"seq":%d}`, seq))
+		time.Sleep(20 * time.Millisecond)
+	}
+	logger.Shutdown()
+
+	files, err := filepath.Glob(filepath.Join(tmpDir, "logs", "events-*.json"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one JSON export file, got %v (%v)", files, err)
+	}
+
+	reader, err := faro.SeekEvents(files[0], target)
+	if err != nil {
+		t.Fatalf("SeekEvents failed: %v", err)
+	}
+	defer reader.Close()
+
+	var seqs []int
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var event struct{ Seq int }
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("SeekEvents did not land on a line boundary: %q", scanner.Text())
+		}
+		seqs = append(seqs, event.Seq)
+	}
+
+	if len(seqs) == 0 || seqs[len(seqs)-1] != 39 {
+		t.Fatalf("expected to read through to the last event, got %v", seqs)
+	}
+	first := seqs[0]
+	if first > 20 {
+		t.Errorf("expected reading to start at or before the target event 20, started at %d", first)
+	}
+	// Entries are at least one interval apart and only written with an event, so allow one write gap of slack
+	if earliest := target.Add(-interval - 50*time.Millisecond); written[first].Before(earliest) {
+		t.Errorf("expected reading to start within one index interval of the target, started %s earlier", target.Sub(written[first]))
+	}
+}
+
+func TestKlogVerbosityIsIndependentOfLogLevel(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		logLevel      string
+		klogVerbosity *int
+		expected      string
+		debugLogged   bool
+	}{
+		{name: "info/unset", logLevel: "info", klogVerbosity: nil, expected: "0", debugLogged: false},
+		{name: "debug/unset", logLevel: "debug", klogVerbosity: nil, expected: "1", debugLogged: true},
+		{name: "info/v=4", logLevel: "info", klogVerbosity: &[]int{4}[0], expected: "4", debugLogged: false},
+		{name: "debug/v=0", logLevel: "debug", klogVerbosity: &[]int{0}[0], expected: "0", debugLogged: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			config := &faro.Config{OutputDir: tmpDir, LogLevel: tc.logLevel, KlogVerbosity: tc.klogVerbosity}
+			logger, err := faro.NewLogger(config)
+			if err != nil {
+				t.Fatalf("Failed to create logger: %v", err)
+			}
+			defer logger.Shutdown()
+
+			if v := flag.Lookup("v").Value.String(); v != tc.expected {
+				t.Errorf("expected klog -v %s, got %s", tc.expected, v)
+			}
+
+			logger.Debug("test", "faro debug message")
+			files, err := filepath.Glob(filepath.Join(tmpDir, "logs", "faro-*.log"))
+			if err != nil || len(files) == 0 {
+				t.Fatalf("No log file found: %v", err)
+			}
+			content, err := os.ReadFile(files[0])
+			if err != nil {
+				t.Fatalf("Failed to read log file: %v", err)
+			}
+			if logged := strings.Contains(string(content), "faro debug message"); logged != tc.debugLogged {
+				t.Errorf("expected debug message logged=%t with log_level %s, got %t", tc.debugLogged, tc.logLevel, logged)
+			}
+		})
+	}
+}
+
+func TestJSONRotationCompressesClosedSegments(t *testing.T) {
+	config := &faro.Config{
+		OutputDir:           t.TempDir(),
+		LogLevel:            "info",
+		JsonExport:          true,
+		JsonCompressRotated: true,
+	}
+	logger, err := faro.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		logger.Info("controller", fmt.Sprintf(`{"eventType":"ADDED","gvr":"v1/configmaps","name":"before-%d"}`, i))
+	}
+	if err := logger.RotateJSON(); err != nil {
+		t.Fatalf("RotateJSON failed: %v", err)
+	}
+	logger.Info("controller", `{"eventType":"ADDED","gvr":"v1/configmaps","name":"after"}`)
+	logger.Shutdown() // Waits for the background compression
+
+	archives, _ := filepath.Glob(filepath.Join(config.GetLogDir(), "events-*-001.json*"))
+	if len(archives) != 1 || !strings.HasSuffix(archives[0], ".json.gz") {
+		t.Fatalf("expected only the gzipped segment to remain, got %v", archives)
+	}
+	file, err := os.Open(archives[0])
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("archive is not valid gzip: %v", err)
+	}
+	var names []string
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var event faro.JSONEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid JSON line in archive %q: %v", scanner.Text(), err)
+		}
+		names = append(names, event.Name)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+	if strings.Join(names, ",") != "before-0,before-1,before-2" {
+		t.Errorf("expected the events written before rotation in the archive, got %v", names)
+	}
+
+	// Test helpers read archives and the active segment alike
+	if events := readJSONEvents(t, config); len(events) != 4 {
+		t.Errorf("expected 4 events across segments, got %d: %+v", len(events), events)
+	}
+}
@@ -0,0 +1,484 @@
+package unit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+// freePort returns a TCP port that is currently free on localhost
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// enableTestMetrics turns on the metrics server on a free localhost port and returns its base URL
+func enableTestMetrics(t *testing.T, config *faro.Config) string {
+	t.Helper()
+
+	port := freePort(t)
+	config.Metrics = faro.MetricsConfig{
+		Enabled:  true,
+		Port:     port,
+		BindAddr: "127.0.0.1",
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d", port)
+}
+
+// httpGet fetches url, retrying while the metrics server starts up
+func httpGet(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := http.Get(url)
+		if err == nil {
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response from %s: %v", url, err)
+			}
+			return resp.StatusCode, string(body)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Failed to GET %s: %v", url, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestConfigEndpointReflectsLoadedConfig(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t,
+		faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"prod", "staging"}, LabelSelector: "app=web"},
+		faro.ResourceConfig{GVR: "v1/namespaces"},
+	)
+	baseURL := enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	status, body := httpGet(t, baseURL+"/config")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 from /config, got %d: %s", status, body)
+	}
+
+	var normalized map[string][]faro.NormalizedConfig
+	if err := json.Unmarshal([]byte(body), &normalized); err != nil {
+		t.Fatalf("Failed to decode /config response: %v\n%s", err, body)
+	}
+	if len(normalized) != 2 {
+		t.Errorf("expected 2 GVRs, got %d: %s", len(normalized), body)
+	}
+	configMaps := normalized["v1/configmaps"]
+	if len(configMaps) != 1 || len(configMaps[0].NamespaceNames) != 2 || configMaps[0].LabelSelector != "app=web" {
+		t.Errorf("unexpected v1/configmaps entry: %+v", configMaps)
+	}
+}
+
+func TestConfigEndpointRedactsSelectors(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"prod"}, LabelSelector: "team=secret-project"})
+	baseURL := enableTestMetrics(t, config)
+	config.Metrics.RedactConfigSelectors = true
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	_, body := httpGet(t, baseURL+"/config")
+	var normalized map[string][]faro.NormalizedConfig
+	if err := json.Unmarshal([]byte(body), &normalized); err != nil {
+		t.Fatalf("Failed to decode /config response: %v\n%s", err, body)
+	}
+	if selector := normalized["v1/configmaps"][0].LabelSelector; selector != "REDACTED" {
+		t.Errorf("expected redacted label selector, got %q", selector)
+	}
+}
+
+func TestConfigEndpointServesResourcesAddedAtRuntime(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"prod"}})
+	baseURL := enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	// Append while /config is being served; run with -race to catch unguarded access
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			controller.AddResources([]faro.ResourceConfig{{GVR: "v1/secrets", NamespaceNames: []string{fmt.Sprintf("ns-%d", i)}}})
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		if status, body := httpGet(t, baseURL+"/config"); status != http.StatusOK {
+			t.Fatalf("expected 200 from /config, got %d: %s", status, body)
+		}
+	}
+	<-done
+
+	_, body := httpGet(t, baseURL+"/config")
+	var normalized map[string][]faro.NormalizedConfig
+	if err := json.Unmarshal([]byte(body), &normalized); err != nil {
+		t.Fatalf("Failed to decode /config response: %v\n%s", err, body)
+	}
+	if secrets := normalized["v1/secrets"]; len(secrets) != 20 {
+		t.Errorf("expected 20 v1/secrets entries after AddResources, got %d", len(secrets))
+	}
+}
+
+func TestDiscoveryGaugesMatchDiscoveredResources(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"default"}})
+	baseURL := enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	status, body := httpGet(t, baseURL+"/metrics")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 from /metrics, got %d", status)
+	}
+
+	wantResources := fmt.Sprintf("faro_discovered_resources_total %d\n", len(controller.GetDiscoveredResources()))
+	if !strings.Contains(body, wantResources) {
+		t.Errorf("expected %q in /metrics output", strings.TrimSpace(wantResources))
+	}
+	if !strings.Contains(body, "faro_discovered_groups_total 1\n") {
+		t.Errorf("expected one discovered group (core) in /metrics output")
+	}
+}
+
+// namedHandler is a recordingHandler reporting a fixed name for handler metrics
+type namedHandler struct {
+	recordingHandler
+	name string
+}
+
+func (n *namedHandler) Name() string { return n.name }
+
+func TestHandlerDurationHistogramRecordsSamples(t *testing.T) {
+	client, _ := newFakeClient(newConfigMap("default", "app-config", "uid-1", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"default"}})
+	baseURL := enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	named := &namedHandler{name: "audit-sink"}
+	unnamed := &recordingHandler{}
+	controller.AddEventHandler(unnamed)
+	controller.AddEventHandler(named)
+	startTestController(t, controller)
+
+	waitFor(t, "both handlers to receive the event", func() bool {
+		return len(named.Events()) == 1 && len(unnamed.Events()) == 1
+	})
+
+	for _, want := range []string{
+		`faro_event_handler_duration_seconds_count{handler="audit-sink"} 1`,
+		`faro_event_handler_duration_seconds_count{handler="handler-0"} 1`,
+	} {
+		waitFor(t, want, func() bool {
+			_, body := httpGet(t, baseURL+"/metrics")
+			return strings.Contains(body, want)
+		})
+	}
+}
+
+// scrapeOpenMetrics fetches url asking for the OpenMetrics format, which carries exemplars
+func scrapeOpenMetrics(t *testing.T, url string) string {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response from %s: %v", url, err)
+	}
+	return string(body)
+}
+
+func TestHandlerDurationExemplarsCarryTraceID(t *testing.T) {
+	client, _ := newFakeClient(newConfigMap("default", "app-config", "uid-1", nil))
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"default"}})
+	baseURL := enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	controller.SetTraceIDFunc(func(event faro.MatchedEvent) string {
+		return "trace-" + string(event.Object.GetUID())
+	})
+	handler := &namedHandler{name: "audit-sink"}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "handler to receive the event", func() bool { return len(handler.Events()) == 1 })
+
+	want := `# {trace_id="trace-uid-1"}`
+	waitFor(t, "trace_id exemplar in OpenMetrics output", func() bool {
+		return strings.Contains(scrapeOpenMetrics(t, baseURL+"/metrics"), want)
+	})
+
+	// The classic text format has no exemplars
+	if _, body := httpGet(t, baseURL+"/metrics"); strings.Contains(body, "trace_id") {
+		t.Errorf("expected no exemplars in the Prometheus text format")
+	}
+}
+
+func TestReadyEndpointWaitsForInformerSync(t *testing.T) {
+	client, dynamicClient := newFakeClient(newConfigMap("default", "app-config", "uid-1", nil))
+
+	// Hold the initial list until the test has seen /ready report 503
+	release := make(chan struct{})
+	dynamicClient.PrependReactor("list", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		<-release
+		return false, nil, nil
+	})
+
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"default"}})
+	baseURL := enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	if status, _ := httpGet(t, baseURL+"/health"); status != http.StatusServiceUnavailable {
+		t.Errorf("expected /health to return 503 before Start, got %d", status)
+	}
+	startTestController(t, controller)
+
+	if status, _ := httpGet(t, baseURL+"/ready"); status != http.StatusServiceUnavailable {
+		t.Errorf("expected /ready to return 503 before informers sync, got %d", status)
+	}
+	if controller.Ready() {
+		t.Error("expected Ready() to be false before informers sync")
+	}
+	if status, _ := httpGet(t, baseURL+"/health"); status != http.StatusOK {
+		t.Errorf("expected /health to return 200 while running, got %d", status)
+	}
+
+	close(release)
+	waitFor(t, "/ready to return 200", func() bool {
+		status, _ := httpGet(t, baseURL+"/ready")
+		return status == http.StatusOK
+	})
+	if !controller.Ready() || !controller.Healthy() {
+		t.Error("expected Ready() and Healthy() after sync")
+	}
+}
+
+func TestFilteredEventsCounterReportsNamespaceMismatch(t *testing.T) {
+	client, _ := newFakeClient(newObject("v1", "Namespace", "", "kube-system", "uid-ns", nil))
+	// Namespaces are cluster-scoped, so namespace_names can never match them
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/namespaces", NamespaceNames: []string{"test-ns"}})
+	baseURL := enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+
+	want := `faro_events_filtered_total{gvr="v1/namespaces",reason="namespace"} 1`
+	waitFor(t, want, func() bool {
+		_, body := httpGet(t, baseURL+"/metrics")
+		return strings.Contains(body, want)
+	})
+	if events := handler.Events(); len(events) != 0 {
+		t.Errorf("expected the filtered event not to reach handlers, got %d events", len(events))
+	}
+}
+
+func TestGVRPerInformerReportsConfigFormat(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	config.Namespaces = []faro.NamespaceConfig{{
+		NameSelector: "test-ns",
+		Resources:    map[string]faro.ResourceDetails{"v1/secrets": {}},
+	}}
+	baseURL := enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	for _, want := range []string{
+		`faro_gvr_per_informer{config_format="resource",gvr="v1/configmaps",namespace_scoped="true"} 1`,
+		`faro_gvr_per_informer{config_format="namespace",gvr="v1/secrets",namespace_scoped="true"} 1`,
+	} {
+		waitFor(t, want, func() bool {
+			_, body := httpGet(t, baseURL+"/metrics")
+			return strings.Contains(body, want)
+		})
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate for 127.0.0.1 and its key into dir
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string, pool *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "faro-test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	pool = x509.NewCertPool()
+	pool.AddCert(cert)
+	return certFile, keyFile, pool
+}
+
+func TestMetricsServerTLSAndBasicAuth(t *testing.T) {
+	client, _ := newFakeClient()
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	baseURL := strings.Replace(enableTestMetrics(t, config), "http://", "https://", 1)
+	certFile, keyFile, pool := writeTestCertificate(t, t.TempDir())
+	config.Metrics.TLSCertFile = certFile
+	config.Metrics.TLSKeyFile = keyFile
+	config.Metrics.BasicAuthUser = "prometheus"
+	config.Metrics.BasicAuthPassword = "s3cret"
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	startTestController(t, controller)
+
+	httpsClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+	get := func(path, user, password string) int {
+		t.Helper()
+		request, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+		if err != nil {
+			t.Fatalf("Failed to build request: %v", err)
+		}
+		if user != "" {
+			request.SetBasicAuth(user, password)
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			resp, err := httpsClient.Do(request)
+			if err == nil {
+				resp.Body.Close()
+				return resp.StatusCode
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Failed to GET %s over TLS: %v", path, err)
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	if status := get("/metrics", "", ""); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 without credentials, got %d", status)
+	}
+	if status := get("/metrics", "prometheus", "wrong"); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 with a wrong password, got %d", status)
+	}
+	if status := get("/metrics", "prometheus", "s3cret"); status != http.StatusOK {
+		t.Errorf("expected 200 with credentials, got %d", status)
+	}
+	if status := get("/config", "", ""); status != http.StatusUnauthorized {
+		t.Errorf("expected /config to require credentials, got %d", status)
+	}
+	if status := get("/health", "", ""); status == http.StatusUnauthorized {
+		t.Error("expected /health to stay unauthenticated for probes")
+	}
+
+	// Plaintext is not served
+	if resp, err := http.Get(strings.Replace(baseURL, "https://", "http://", 1) + "/metrics"); err == nil {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			t.Error("expected plaintext requests to be rejected")
+		}
+	}
+}
+
+func TestEventCountMatchesEmittedEvents(t *testing.T) {
+	client, dynamicClient := newFakeClient(
+		newConfigMap("test-ns", "first", "uid-1", nil),
+		newConfigMap("test-ns", "second", "uid-2", nil),
+	)
+	config := newTestConfig(t, faro.ResourceConfig{GVR: "v1/configmaps", NamespaceNames: []string{"test-ns"}})
+	enableTestMetrics(t, config)
+
+	controller := faro.NewController(client, newTestLogger(t, config), config)
+	handler := &recordingHandler{}
+	controller.AddEventHandler(handler)
+	startTestController(t, controller)
+	waitFor(t, "initial ADDED events", func() bool { return countEvents(handler.Events(), "ADDED") == 2 })
+
+	configMaps := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test-ns")
+	if err := configMaps.Delete(context.Background(), "first", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: %v", err)
+	}
+	waitFor(t, "DELETED event", func() bool { return countEvents(handler.Events(), "DELETED") == 1 })
+
+	metrics := controller.Metrics()
+	for eventType, want := range map[string]int{"ADDED": 2, "DELETED": 1, "UPDATED": 0} {
+		if got := metrics.EventCount("v1/configmaps", eventType); got != float64(want) {
+			t.Errorf("expected EventCount %s = %d, got %v", eventType, want, got)
+		}
+		if emitted := countEvents(handler.Events(), eventType); emitted != want {
+			t.Errorf("expected %d %s events delivered, got %d", want, eventType, emitted)
+		}
+	}
+	if got := metrics.EventCount("v1/secrets", "ADDED"); got != 0 {
+		t.Errorf("expected no events for an unwatched GVR, got %v", got)
+	}
+}
@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+// acceptLines accepts one connection on listener and sends every JSON line it reads to lines
+func acceptLines(t *testing.T, listener net.Listener, lines chan<- faro.JSONEvent) net.Conn {
+	t.Helper()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("Failed to accept sink connection: %v", err)
+	}
+	go func() {
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			var event faro.JSONEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err == nil {
+				lines <- event
+			}
+		}
+	}()
+	return conn
+}
+
+// receiveEvent waits for the next event read from the socket
+func receiveEvent(t *testing.T, lines <-chan faro.JSONEvent) faro.JSONEvent {
+	t.Helper()
+
+	select {
+	case event := <-lines:
+		return event
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for an event on the socket")
+		return faro.JSONEvent{}
+	}
+}
+
+func TestUnixSocketSinkResumesAfterConsumerRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "faro.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Failed to listen on %s: %v", path, err)
+	}
+
+	sink := faro.NewUnixSocketSink(path, 10)
+	defer sink.Close()
+
+	lines := make(chan faro.JSONEvent, 10)
+	event := faro.MatchedEvent{EventType: "ADDED", GVR: "v1/configmaps", Object: newConfigMap("test-ns", "first", "uid-1", nil), Timestamp: time.Now()}
+	if err := sink.OnMatched(event); err != nil {
+		t.Fatalf("OnMatched failed: %v", err)
+	}
+	conn := acceptLines(t, listener, lines)
+	if received := receiveEvent(t, lines); received.Name != "first" || received.EventType != "ADDED" || received.GVR != "v1/configmaps" {
+		t.Fatalf("unexpected event on the socket: %+v", received)
+	}
+
+	// The consumer restarts: events sent while it is gone are delivered once it listens again
+	conn.Close()
+	listener.Close()
+	os.Remove(path)
+	time.Sleep(100 * time.Millisecond)
+	event.Object = newConfigMap("test-ns", "second", "uid-2", nil)
+	if err := sink.OnMatched(event); err != nil {
+		t.Fatalf("OnMatched failed: %v", err)
+	}
+
+	listener, err = net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Failed to listen on %s again: %v", path, err)
+	}
+	defer listener.Close()
+	acceptLines(t, listener, lines)
+	if received := receiveEvent(t, lines); received.Name != "second" {
+		t.Fatalf("expected delivery to resume with the next event, got %+v", received)
+	}
+	if dropped := sink.Dropped(); dropped != 0 {
+		t.Errorf("expected no dropped events, got %d", dropped)
+	}
+}
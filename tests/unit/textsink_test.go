@@ -0,0 +1,53 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+func TestTextSinkRendersLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.txt")
+	sink, err := faro.NewTextSink(path, "{{.EventType}} {{.GVR}} {{.Namespace}}/{{.Name}} uid={{.UID}} app={{index .Labels \"app\"}}")
+	if err != nil {
+		t.Fatalf("Failed to create text sink: %v", err)
+	}
+
+	events := []faro.MatchedEvent{
+		{EventType: "ADDED", GVR: "v1/configmaps", Object: newConfigMap("test-ns", "settings", "uid-1", map[string]string{"app": "web"})},
+		{EventType: "DELETED", GVR: "v1/configmaps", Object: newConfigMap("test-ns", "settings", "uid-1", map[string]string{"app": "web"})},
+	}
+	for _, event := range events {
+		if err := sink.OnMatched(event); err != nil {
+			t.Fatalf("OnMatched failed: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read sink file: %v", err)
+	}
+	expected := "ADDED v1/configmaps test-ns/settings uid=uid-1 app=web\n" +
+		"DELETED v1/configmaps test-ns/settings uid=uid-1 app=web\n"
+	if string(content) != expected {
+		t.Errorf("unexpected sink output:\n got: %q\nwant: %q", content, expected)
+	}
+}
+
+func TestTextSinkRejectsInvalidLayout(t *testing.T) {
+	for name, layout := range map[string]string{
+		"syntax error":  "{{.EventType",
+		"unknown field": "{{.EventType}} {{.Nmae}}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := faro.NewTextSink(filepath.Join(t.TempDir(), "events.txt"), layout); err == nil {
+				t.Errorf("expected layout %q to be rejected", layout)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+func TestDeletedObjectFromTombstone(t *testing.T) {
+	tests := []struct {
+		name          string
+		tombstone     cache.DeletedFinalStateUnknown
+		wantResult    string
+		wantNamespace string
+		wantName      string
+		wantUID       string
+	}{
+		{
+			name:          "unstructured object",
+			tombstone:     cache.DeletedFinalStateUnknown{Key: "ns/cm", Obj: newConfigMap("ns", "cm", "uid-1", nil)},
+			wantResult:    faro.TombstoneResultUnstructured,
+			wantNamespace: "ns",
+			wantName:      "cm",
+			wantUID:       "uid-1",
+		},
+		{
+			name: "typed object with metadata",
+			tombstone: cache.DeletedFinalStateUnknown{Key: "ns/cm", Obj: &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cm", UID: "uid-2"},
+			}},
+			wantResult:    faro.TombstoneResultReconstructed,
+			wantNamespace: "ns",
+			wantName:      "cm",
+			wantUID:       "uid-2",
+		},
+		{
+			name:          "unknown object rebuilt from key",
+			tombstone:     cache.DeletedFinalStateUnknown{Key: "ns/cm", Obj: "not an object"},
+			wantResult:    faro.TombstoneResultReconstructed,
+			wantNamespace: "ns",
+			wantName:      "cm",
+		},
+		{
+			name:       "unknown object without key",
+			tombstone:  cache.DeletedFinalStateUnknown{Obj: 42},
+			wantResult: faro.TombstoneResultDropped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, result := faro.DeletedObjectFromTombstone(tt.tombstone)
+			if result != tt.wantResult {
+				t.Fatalf("expected result %q, got %q", tt.wantResult, result)
+			}
+			if tt.wantResult == faro.TombstoneResultDropped {
+				if obj != nil {
+					t.Errorf("expected no object for dropped tombstone, got %v", obj)
+				}
+				return
+			}
+			if obj.GetNamespace() != tt.wantNamespace || obj.GetName() != tt.wantName || string(obj.GetUID()) != tt.wantUID {
+				t.Errorf("unexpected object %s/%s uid=%q", obj.GetNamespace(), obj.GetName(), obj.GetUID())
+			}
+		})
+	}
+}
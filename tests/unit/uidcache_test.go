@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"testing"
+
+	faro "github.com/T0MASD/faro/pkg"
+)
+
+func TestUIDCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	var evicted []string
+	cache := faro.NewUIDCache(2, func(key string) {
+		evicted = append(evicted, key)
+	})
+
+	cache.Store("v1/configmaps/ns/a", "uid-a")
+	cache.Store("v1/configmaps/ns/b", "uid-b")
+
+	// Touch "a" so "b" becomes the least recently used entry
+	if uid, ok := cache.Load("v1/configmaps/ns/a"); !ok || uid != "uid-a" {
+		t.Fatalf("expected uid-a, got %q (found: %t)", uid, ok)
+	}
+
+	cache.Store("v1/configmaps/ns/c", "uid-c")
+
+	if cache.Len() != 2 {
+		t.Errorf("expected cache to hold 2 entries at the cap, got %d", cache.Len())
+	}
+	if len(evicted) != 1 || evicted[0] != "v1/configmaps/ns/b" {
+		t.Errorf("expected only v1/configmaps/ns/b to be evicted, got %v", evicted)
+	}
+	if _, ok := cache.Load("v1/configmaps/ns/b"); ok {
+		t.Error("expected evicted key to be absent")
+	}
+	for _, key := range []string{"v1/configmaps/ns/a", "v1/configmaps/ns/c"} {
+		if _, ok := cache.Load(key); !ok {
+			t.Errorf("expected %s to remain cached", key)
+		}
+	}
+}
+
+func TestUIDCacheUnbounded(t *testing.T) {
+	cache := faro.NewUIDCache(0, func(key string) {
+		t.Errorf("unexpected eviction of %s", key)
+	})
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		cache.Store(key, "uid-"+key)
+	}
+	cache.Delete("a")
+
+	if cache.Len() != 3 {
+		t.Errorf("expected 3 entries, got %d", cache.Len())
+	}
+}